@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/insolar/insconfig"
+
+	"github.com/insolar/consensus-reports/pkg/gc"
+	"github.com/insolar/consensus-reports/pkg/storage"
+)
+
+func main() {
+
+	dryRun := flag.Bool("dry-run", false, "Only list runs which would be removed")
+	cfg := gc.Config{}
+	params := insconfig.Params{
+		EnvPrefix:       "report",
+		FileNotRequired: true,
+		ConfigPathGetter: &insconfig.FlagPathGetter{
+			GoFlags: flag.CommandLine,
+		},
+	}
+	insConfigurator := insconfig.New(params)
+	err := insConfigurator.Load(&cfg)
+	checkError(err)
+
+	err = cfg.Validate()
+	checkError(err)
+
+	err = insconfig.NewYamlDumper(cfg).DumpTo(log.Writer())
+	checkError(err)
+
+	client := storage.NewWebdavClient(cfg.WebDav.Host, cfg.WebDav.Username, cfg.WebDav.Password, cfg.WebDav.Timeout)
+	err = gc.Collect(client, cfg.Retention, *dryRun, os.Stdout)
+	checkError(err)
+}
+
+func checkError(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
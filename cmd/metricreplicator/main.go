@@ -6,15 +6,21 @@ import (
 	"fmt"
 	"github.com/insolar/insconfig"
 	"log"
+	"time"
 
 	"github.com/insolar/consensus-reports/pkg/metricreplicator"
 	"github.com/insolar/consensus-reports/pkg/middleware"
 	"github.com/insolar/consensus-reports/pkg/replicator"
+	"github.com/insolar/consensus-reports/pkg/storage"
 )
 
 func main() {
 
 	removeAfter := flag.Bool("rm", true, "Option to remove tmp dir after work")
+	appendMode := flag.Bool("append", false, "Option to add missing ranges to existing remote directory")
+	force := flag.Bool("force", false, "Option to grab ranges that already exist in remote directory in append mode")
+	overwrite := flag.Bool("overwrite", false, "Option to replace existing remote directory")
+	breakLock := flag.Bool("break-lock", false, "Option to remove lock of remote directory held by another writer")
 	cfg := middleware.Config{}
 	params := insconfig.Params{
 		EnvPrefix:       "report",
@@ -30,6 +36,9 @@ func main() {
 	err = cfg.Validate()
 	checkError(err)
 
+	cfg.WebDav.Directory, err = cfg.WebDav.RunDirectory(cfg.Git.Branch, cfg.Git.Hash)
+	checkError(err)
+
 	err = insconfig.NewYamlDumper(cfg).DumpTo(log.Writer())
 	checkError(err)
 
@@ -38,47 +47,117 @@ func main() {
 		log.Fatalf("failed to init replicator: %v", err)
 	}
 
-	if err := Run(repl, cfg, *removeAfter); err != nil {
-		log.Fatalf("failed to replicate metrics: %v", err)
+	client := storage.NewWebdavClient(cfg.WebDav.Host, cfg.WebDav.Username, cfg.WebDav.Password, cfg.WebDav.Timeout)
+	lock, err := storage.AcquireLock(client, cfg.WebDav.LockConfig(*breakLock))
+	if err != nil {
+		log.Fatalf("failed to lock remote dir: %v", err)
+	}
+
+	opts := Options{
+		RemoveAfter: *removeAfter,
+		Append:      *appendMode,
+		Force:       *force,
+		Overwrite:   *overwrite,
+	}
+	runErr := Run(repl, cfg, opts)
+	if err := lock.Release(); err != nil {
+		log.Printf("failed to release lock: %v", err)
+	}
+	if runErr != nil {
+		log.Fatalf("failed to replicate metrics: %v", runErr)
 	}
 
 	fmt.Println("Done!")
 }
 
-func Run(repl replicator.Replicator, cfg middleware.Config, removeAfter bool) error {
+type Options struct {
+	RemoveAfter bool
+	// Append adds ranges to existing remote directory instead of replicating all of them.
+	Append bool
+	// Force grabs ranges even if they are already in remote directory.
+	Force bool
+	// Overwrite replaces existing remote directory instead of failing.
+	Overwrite bool
+}
+
+func Run(repl replicator.Replicator, cfg middleware.Config, opts Options) error {
 	cleanDir, err := metricreplicator.MakeTmpDir(cfg.TmpDir)
-	if removeAfter {
+	if opts.RemoveAfter {
 		defer cleanDir()
 	}
 	if err != nil {
 		return err
 	}
 
+	commitDate, err := cfg.CommitTime()
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
+	indexFilename := replicator.DefaultConfigFilename
+	loaderCfg := cfg.LoaderConfig()
+	loaderCfg.KeepExisting = opts.Append
+	loaderCfg.Overwrite = opts.Overwrite
+	periods := middleware.GroupsToReplicatorPeriods(cfg.Groups)
+
+	var remoteCfg replicator.OutputConfig
+	if opts.Append {
+		remoteCfg, _, err = repl.DownloadConfigFile(ctx, loaderCfg, indexFilename)
+		if err != nil {
+			return err
+		}
+		if !opts.Force {
+			periods = missingPeriods(periods, remoteCfg.Files)
+		}
+		log.Printf("append mode: %d existing files, %d ranges to grab", len(remoteCfg.Files), len(periods))
+	}
 
-	files, charts, err := repl.GrabRecords(ctx, cfg.Quantiles, middleware.GroupsToReplicatorPeriods(cfg.Groups))
+	files, charts, err := repl.GrabRecords(ctx, cfg.Quantiles, periods)
 	if err != nil {
 		return err
 	}
 
-	indexFilename := replicator.DefaultConfigFilename
-	outputCfg := replicator.OutputConfig{
+	outputCfg := remoteCfg.Merge(replicator.OutputConfig{
 		Charts:    charts,
 		Quantiles: cfg.Quantiles,
-	}
+		Files:     files,
+		Run: &replicator.RunMetadata{
+			Branch:     cfg.Git.Branch,
+			Hash:       cfg.Git.Hash,
+			Date:       time.Now().UTC(),
+			CommitDate: commitDate,
+			Components: middleware.ToReplicatorComponents(cfg.Components),
+		},
+	})
 	if err := repl.MakeConfigFile(ctx, outputCfg, indexFilename); err != nil {
 		return err
 	}
 
 	files = append(files, indexFilename)
 
-	loaderCfg := cfg.LoaderConfig()
 	if err := repl.UploadFiles(ctx, loaderCfg, files); err != nil {
 		return err
 	}
 	return nil
 }
 
+// missingPeriods filters out periods with files from existing list.
+func missingPeriods(periods []replicator.PeriodInfo, existing []string) []replicator.PeriodInfo {
+	exists := make(map[string]bool, len(existing))
+	for _, f := range existing {
+		exists[f] = true
+	}
+
+	result := make([]replicator.PeriodInfo, 0, len(periods))
+	for _, p := range periods {
+		if !exists[metricreplicator.PeriodFilename(p)] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
 func checkError(err error) {
 	if err != nil {
 		log.Fatalln(err)
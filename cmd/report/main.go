@@ -1,19 +1,42 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"io/ioutil"
 	"log"
-	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/insolar/insconfig"
 	"go.uber.org/zap/buffer"
 
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
 	"github.com/insolar/consensus-reports/pkg/report"
+	"github.com/insolar/consensus-reports/pkg/server"
 )
 
 func main() {
 
 	var serveAddress = flag.String("serve", "", "Serve html on address")
+	var serveRoot = flag.String("serve-root", "", "Browse all runs under root directory in serve mode")
+	var prometheus = flag.String("prometheus", "", "Serve live report of ranges queried from prometheus host at /live")
+	var templateDir = flag.String("template-dir", "", "Directory with template.html replacing bundled html template")
+	var breakLock = flag.Bool("break-lock", false, "Remove lock of report directory held by another writer")
+	var cdn = flag.Bool("cdn", false, "Load chart library from CDN instead of inlining it into html")
+	var baseline = flag.String("baseline", "", "Compare report directory with baseline run directory")
+	var rulesFile = flag.String("rules", "", "Check report with threshold rules from yaml file")
+	var check = flag.Bool("check", false, "Check thresholds and baseline without writing html, exit code is 0 on pass, 1 on regression, 2 on data errors")
+	var verdictFile = flag.String("verdict", report.DefaultVerdictFileName, "Write json verdict of check mode to file")
+	var markdownFile = flag.String("markdown", "", "Write markdown summary to file, e.g. for pull request comment")
+	var reportURL = flag.String("report-url", "", "Link to html report in markdown summary, default is webdav URL of report")
+	var junitFile = flag.String("junit", "", "Write threshold checks to junit xml file, requires rules")
+	var images = flag.String("images", "", "Comma separated chart image formats to upload next to html and embed into markdown: svg, png")
+	var terminal = flag.Bool("terminal", false, "Print charts and values to terminal without writing anything to storage")
+	var width = flag.Int("width", report.DefaultTerminalWidth, "Width of terminal charts in characters")
+	var height = flag.Int("height", report.DefaultTerminalHeight, "Height of terminal charts in lines")
+	var format = flag.String("format", report.FormatHTML, "Comma separated report formats to upload: html, csv, json")
 	cfg := report.Config{}
 	params := insconfig.Params{
 		EnvPrefix:       "report",
@@ -26,40 +49,224 @@ func main() {
 	err := insConfigurator.Load(&cfg)
 	checkError(err)
 
+	cfg.Webdav.Directory, err = cfg.Webdav.RunDirectory(cfg.Git.Branch, cfg.Git.Hash)
+	checkError(err)
+	if *baseline != "" {
+		cfg.Compare.Baseline = *baseline
+	}
+	if *rulesFile != "" {
+		cfg.Rules.File = *rulesFile
+	}
+	if *serveRoot != "" {
+		cfg.Serve.Root = *serveRoot
+	}
+	if *prometheus != "" {
+		cfg.Live.Prometheus = *prometheus
+	}
+	if *templateDir != "" {
+		cfg.Template.Dir = *templateDir
+	}
+
 	err = insconfig.NewYamlDumper(cfg).DumpTo(log.Writer())
 	checkError(err)
 
+	formats, err := report.ParseFormats(*format)
+	checkError(err)
+	imageFormats, err := report.ParseImageFormats(*images)
+	checkError(err)
+
 	client := report.CreateWebdavClient(cfg)
+	reader, _ := client.CompareReader()
+	var rules *report.Rules
+	if cfg.Rules.File != "" {
+		r, err := report.ReadRules(cfg.Rules.File)
+		checkError(err)
+		rules = &r
+		reader = report.RulesReader{Reader: reader, Rules: r}
+	}
 
+	opts := report.Options{CDN: *cdn, TemplateDir: cfg.Template.Dir}
 	if serveAddress != nil && *serveAddress != "" {
-		serveReport(*serveAddress, client)
-	} else {
-		saveReport(client)
+		checkError(opts.Validate())
+		serveCfg, err := cfg.Serve.LoadSecrets()
+		checkError(err)
+		srv := server.New(serveCfg, reader, opts)
+		if cfg.Serve.Root != "" {
+			srv.Browse(client.Browser(cfg.Serve.Root, rules))
+		}
+		if cfg.Live.Prometheus != "" {
+			grabber, err := metricreplicator.NewGrabber(cfg.Live.Prometheus)
+			checkError(err)
+			srv.Live(report.NewLiveSource(cfg, grabber))
+		}
+		err = serveReport(*serveAddress, serveCfg.TLS.Enabled(), srv)
+		checkError(err)
+		return
+	}
+
+	if *terminal {
+		err = report.MakeTerminal(reader, os.Stdout, report.TerminalOptions{Width: *width, Height: *height})
+		checkError(err)
+		return
+	}
+
+	// all outputs are made from the same data
+	reader = &report.CachedReader{Reader: reader}
+
+	if *markdownFile != "" {
+		mdOpts := report.MarkdownOptions{ReportURL: *reportURL, ImageURL: client.DirectoryURL()}
+		if mdOpts.ReportURL == "" {
+			mdOpts.ReportURL = client.ReportURL()
+		}
+		if len(imageFormats) > 0 {
+			// png is shown by more markdown viewers
+			mdOpts.ImageFormat = imageFormats[len(imageFormats)-1]
+			for _, f := range imageFormats {
+				if f == report.ImageFormatPNG {
+					mdOpts.ImageFormat = f
+				}
+			}
+		}
+		err = saveMarkdown(reader, *markdownFile, mdOpts)
+		checkError(err)
 	}
+
+	if *junitFile != "" {
+		err = saveJUnit(reader, *junitFile)
+		checkError(err)
+	}
+
+	if *check {
+		os.Exit(checkReport(reader, *verdictFile))
+	}
+
+	err = saveReport(client, reader, formats, imageFormats, *breakLock, opts)
+	checkError(err)
 }
 
-func saveReport(client *report.WebdavClient) {
+func saveReport(client *report.WebdavClient, reader report.TemplateDataReader, formats, imageFormats []string, breakLock bool, opts report.Options) error {
+	lock, err := client.Lock(breakLock)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			log.Printf("failed to release lock: %v", err)
+		}
+	}()
+
+	for _, format := range formats {
+		buff := &buffer.Buffer{}
+		if err := report.Render(format, reader, buff, opts); err != nil {
+			return err
+		}
+		if err := client.WriteReportFile(report.ReportFileName(format), buff.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := saveVerdict(client, reader); err != nil {
+		return err
+	}
+
+	if len(imageFormats) == 0 {
+		return nil
+	}
+	images, err := report.MakeChartImages(reader, imageFormats)
+	if err != nil {
+		return err
+	}
+	for _, img := range images {
+		if err := client.WriteReportFile(img.Filename, img.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveVerdict uploads verdict of checked report, so runs can be browsed with it.
+func saveVerdict(client *report.WebdavClient, reader report.TemplateDataReader) error {
+	data, err := reader.ReadTemplateData()
+	if err != nil {
+		return err
+	}
+	if data.Verdict == nil && data.Comparison == nil {
+		return nil
+	}
+	buf, err := report.Check(data).JSON()
+	if err != nil {
+		return err
+	}
+	return client.WriteReportFile(report.DefaultVerdictFileName, buf)
+}
+
+func saveMarkdown(reader report.TemplateDataReader, filename string, opts report.MarkdownOptions) error {
 	buff := &buffer.Buffer{}
-	err := report.MakeReport(client, buff)
-	checkError(err)
+	if err := report.MakeMarkdown(reader, buff, opts); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, buff.Bytes(), 0644)
+}
 
-	err = client.WriteReport(buff.Bytes())
-	checkError(err)
+func saveJUnit(reader report.TemplateDataReader, filename string) error {
+	buff := &buffer.Buffer{}
+	if err := report.MakeJUnit(reader, buff); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, buff.Bytes(), 0644)
 }
 
-func serveReport(serveAddress string, dataReader report.TemplateDataReader) {
-	log.Println("listen at http://" + serveAddress)
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		err := report.MakeReport(dataReader, w)
-		checkError(err)
-	})
+// checkReport prints verdict of reader data and returns exit code.
+func checkReport(reader report.TemplateDataReader, verdictFile string) int {
+	var result report.CheckResult
+	data, err := reader.ReadTemplateData()
+	if err != nil {
+		result = report.CheckError(err)
+	} else {
+		result = report.Check(data)
+	}
 
-	err := http.ListenAndServe(serveAddress, nil)
-	checkError(err)
+	if err := result.WriteSummary(os.Stdout); err != nil {
+		log.Println(err)
+	}
+	if verdictFile != "" {
+		if err := result.WriteFile(verdictFile); err != nil {
+			log.Println(err)
+			return report.ExitCodeDataError
+		}
+	}
+	return result.ExitCode()
+}
+
+// serveReport serves report until SIGINT or SIGTERM.
+func serveReport(serveAddress string, tls bool, srv *server.Server) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case sig := <-signals:
+			log.Printf("got %s signal", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	scheme := "http"
+	if tls {
+		scheme = "https"
+	}
+	log.Printf("listen at %s://%s", scheme, serveAddress)
+	return srv.ListenAndServe(ctx, serveAddress)
 }
 
+// checkError exits with data error code, so check mode never confuses failures with regressions.
 func checkError(err error) {
 	if err != nil {
-		log.Fatalln(err)
+		log.Println(err)
+		os.Exit(report.ExitCodeDataError)
 	}
 }
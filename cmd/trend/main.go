@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+
+	"github.com/insolar/insconfig"
+	"go.uber.org/zap/buffer"
+
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+func main() {
+
+	var out = flag.String("out", "", "Save html to local file instead of uploading it to root directory")
+	var cdn = flag.Bool("cdn", false, "Load chart library from CDN instead of inlining it into html")
+	cfg := report.TrendCommandConfig{}
+	params := insconfig.Params{
+		EnvPrefix:       "report",
+		FileNotRequired: true,
+		ConfigPathGetter: &insconfig.FlagPathGetter{
+			GoFlags: flag.CommandLine,
+		},
+	}
+	insConfigurator := insconfig.New(params)
+	err := insConfigurator.Load(&cfg)
+	checkError(err)
+
+	err = insconfig.NewYamlDumper(cfg).DumpTo(log.Writer())
+	checkError(err)
+
+	reader := report.CreateWebdavClient(cfg.ReportConfig()).TrendReader()
+
+	buff := &buffer.Buffer{}
+	err = report.MakeReport(reader, buff, report.Options{CDN: *cdn, TemplateDir: cfg.Template.Dir})
+	checkError(err)
+
+	if *out != "" {
+		err = ioutil.WriteFile(*out, buff.Bytes(), 0644)
+		checkError(err)
+		log.Println("trend report is saved to " + *out)
+		return
+	}
+
+	filePath, err := reader.WriteReport(buff.Bytes())
+	checkError(err)
+	log.Println("trend report is uploaded to " + filePath)
+}
+
+func checkError(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
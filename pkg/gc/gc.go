@@ -0,0 +1,158 @@
+package gc
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/insolar/consensus-reports/pkg/middleware"
+	"github.com/insolar/consensus-reports/pkg/storage"
+)
+
+// RetentionConfig describes which runs are kept. Run is removed if no rule keeps it.
+type RetentionConfig struct {
+	// Root is a directory with runs, they can be nested in it.
+	Root string `mapstructure:"root" validate:"required"`
+	// KeepLast is count of newest runs kept for every branch.
+	KeepLast int `mapstructure:"keeplast" validate:"min=0"`
+	// KeepYounger keeps runs replicated less than this time ago.
+	KeepYounger time.Duration `mapstructure:"keepyounger" validate:"min=0"`
+	// Pinned are run directories relative to root which are always kept, e.g. baselines.
+	Pinned []string `mapstructure:"pinned"`
+}
+
+type Config struct {
+	WebDav    middleware.WebDavConfig `mapstructure:"webdav" validate:"required"`
+	Retention RetentionConfig         `mapstructure:"retention" validate:"required"`
+}
+
+func (cfg *Config) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(cfg); err != nil {
+		return err
+	}
+	if cfg.Retention.KeepLast == 0 && cfg.Retention.KeepYounger == 0 {
+		return errors.New("keeplast or keepyounger retention rule is required")
+	}
+	return nil
+}
+
+// Filesystem is a remote storage with runs, runs are locked while they are removed.
+type Filesystem interface {
+	storage.DirReader
+	storage.Filesystem
+	RemoveAll(path string) error
+}
+
+// Decision is a result of retention policy for run.
+type Decision struct {
+	Run    storage.Run
+	Keep   bool
+	Reason string
+}
+
+// Select applies retention policy to runs sorted by date, newest first.
+func Select(runs []storage.Run, cfg RetentionConfig, now time.Time) []Decision {
+	pinned := make(map[string]bool, len(cfg.Pinned))
+	for _, p := range cfg.Pinned {
+		pinned[strings.Trim(path.Clean("/"+p), "/")] = true
+	}
+
+	branchCount := make(map[string]int)
+	decisions := make([]Decision, 0, len(runs))
+	for _, r := range runs {
+		branch := r.Index.RunInfo().Branch
+		branchCount[branch]++
+
+		d := Decision{Run: r, Keep: true}
+		switch {
+		case pinned[r.Name(cfg.Root)]:
+			d.Reason = "pinned"
+		case branchCount[branch] <= cfg.KeepLast:
+			d.Reason = fmt.Sprintf("one of last %d runs of branch", cfg.KeepLast)
+		case cfg.KeepYounger > 0 && now.Sub(r.Date()) < cfg.KeepYounger:
+			d.Reason = fmt.Sprintf("younger than %s", cfg.KeepYounger)
+		default:
+			d.Keep = false
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions
+}
+
+// Collect removes runs under root which are not kept by retention policy.
+// Runs locked by writers are never removed. With dryRun nothing is removed, decisions are only printed to out.
+func Collect(fs Filesystem, cfg RetentionConfig, dryRun bool, out io.Writer) error {
+	runs, err := storage.ListRuns(fs, cfg.Root)
+	if err != nil {
+		return errors.Wrap(err, "failed to list runs")
+	}
+
+	action := "remove"
+	if dryRun {
+		action = "would remove"
+	}
+
+	removed := 0
+	for _, d := range Select(runs, cfg, time.Now()) {
+		info := fmt.Sprintf("%s (branch %q, hash %q, date %s)",
+			d.Run.Dir, d.Run.Index.RunInfo().Branch, d.Run.Index.RunInfo().Hash, d.Run.Date().Format(time.RFC3339))
+		if d.Keep {
+			fmt.Fprintf(out, "keep %s: %s\n", info, d.Reason)
+			continue
+		}
+
+		if dryRun {
+			if lock, locked, err := storage.ReadLock(fs, d.Run.Dir); err != nil {
+				return errors.Wrapf(err, "failed to check lock of %s", d.Run.Dir)
+			} else if locked {
+				fmt.Fprintf(out, "keep %s: locked by %s\n", info, lock)
+				continue
+			}
+			fmt.Fprintf(out, "%s %s\n", action, info)
+			continue
+		}
+
+		if holder, locked, err := removeRun(fs, d.Run.Dir); err != nil {
+			return err
+		} else if locked {
+			fmt.Fprintf(out, "keep %s: locked by %s\n", info, holder)
+			continue
+		}
+		fmt.Fprintf(out, "%s %s\n", action, info)
+		removed++
+	}
+
+	if !dryRun {
+		fmt.Fprintf(out, "removed %d of %d runs\n", removed, len(runs))
+	}
+	return nil
+}
+
+// removeRun takes lock of run directory and removes it, so writer can't start between check and removal.
+// Returns holder of lock if run is locked by another writer.
+func removeRun(fs Filesystem, dir string) (storage.LockInfo, bool, error) {
+	lock, err := storage.AcquireLock(fs, storage.LockConfig{Dir: dir})
+	if err != nil {
+		if locked, ok := errors.Cause(err).(*storage.LockedError); ok {
+			return locked.Holder, true, nil
+		}
+		return storage.LockInfo{}, false, errors.Wrapf(err, "failed to lock %s", dir)
+	}
+
+	removeErr := fs.RemoveAll("/" + dir)
+	if err := lock.Release(); err != nil {
+		log.Printf("failed to release lock of %s: %v", dir, err)
+	}
+	if removeErr != nil && !os.IsNotExist(removeErr) {
+		return storage.LockInfo{}, false, errors.Wrapf(removeErr, "failed to remove %s", dir)
+	}
+	return storage.LockInfo{}, false, nil
+}
@@ -0,0 +1,196 @@
+package gc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/middleware"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+	"github.com/insolar/consensus-reports/pkg/storage"
+)
+
+type fileInfo struct {
+	name  string
+	isDir bool
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return 0 }
+func (fi fileInfo) Mode() os.FileMode  { return 0644 }
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return fi.isDir }
+func (fi fileInfo) Sys() interface{}   { return nil }
+
+// memFS keeps files by absolute path, directories are implied by file paths.
+type memFS map[string][]byte
+
+func (fs memFS) ReadDir(dir string) ([]os.FileInfo, error) {
+	prefix := strings.TrimSuffix(dir, "/") + "/"
+	seen := map[string]bool{}
+	var entries []os.FileInfo
+	for p := range fs {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(p, prefix)
+		name := strings.Split(rest, "/")[0]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entries = append(entries, fileInfo{name: name, isDir: strings.Contains(rest, "/")})
+	}
+	if len(entries) == 0 {
+		return nil, &os.PathError{Op: "ReadDir", Path: dir, Err: os.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (fs memFS) Read(p string) ([]byte, error) {
+	data, ok := fs[p]
+	if !ok {
+		return nil, &os.PathError{Op: "Read", Path: p, Err: os.ErrNotExist}
+	}
+	return data, nil
+}
+
+// ReadVersion uses file content as its version.
+func (fs memFS) ReadVersion(p string) ([]byte, string, error) {
+	data, err := fs.Read(p)
+	return data, string(data), err
+}
+
+func (fs memFS) WriteIfMatch(p string, data []byte, version string) (string, error) {
+	current, ok := fs[p]
+	if ok != (version != "") || string(current) != version {
+		return "", &os.PathError{Op: "WriteIfMatch", Path: p, Err: errors.New("412")}
+	}
+	fs[p] = data
+	return string(data), nil
+}
+
+func (fs memFS) RemoveIfMatch(p, version string) error {
+	if string(fs[p]) != version {
+		return &os.PathError{Op: "RemoveIfMatch", Path: p, Err: errors.New("412")}
+	}
+	delete(fs, p)
+	return nil
+}
+
+// lockedRemoveFS checks that directories are locked while they are removed.
+type lockedRemoveFS struct {
+	memFS
+	t *testing.T
+}
+
+func (fs lockedRemoveFS) RemoveAll(dir string) error {
+	require.Contains(fs.t, fs.memFS, storage.LockFilePath(dir), "%s is removed without lock", dir)
+	return fs.memFS.RemoveAll(dir)
+}
+
+func (fs memFS) RemoveAll(dir string) error {
+	for p := range fs {
+		if strings.HasPrefix(p, dir+"/") {
+			delete(fs, p)
+		}
+	}
+	return nil
+}
+
+func (fs memFS) addRun(t *testing.T, dir, branch string, date time.Time) {
+	data, err := json.Marshal(replicator.OutputConfig{
+		Run: &replicator.RunMetadata{Branch: branch, Hash: path.Base(dir), Date: date},
+	})
+	require.NoError(t, err)
+	fs[path.Join("/", dir, replicator.DefaultConfigFilename)] = data
+	fs[path.Join("/", dir, "network_size_5.json")] = []byte("{}")
+}
+
+func TestCollect(t *testing.T) {
+	now := time.Now()
+	fs := memFS{}
+	fs.addRun(t, "root/master/m1", "master", now.Add(-10*24*time.Hour))
+	fs.addRun(t, "root/master/m2", "master", now.Add(-9*24*time.Hour))
+	fs.addRun(t, "root/master/m3", "master", now.Add(-8*24*time.Hour))
+	fs.addRun(t, "root/master/m4", "master", now.Add(-time.Hour))
+	fs.addRun(t, "root/feature/f1", "feature", now.Add(-10*24*time.Hour))
+	fs.addRun(t, "root/feature/f2", "feature", now.Add(-9*24*time.Hour))
+	fs.addRun(t, "root/locked", "feature", now.Add(-20*24*time.Hour))
+	fs["/root/locked.lock"] = []byte(`{"owner":"job-1","expires_at":"` + now.Add(time.Hour).Format(time.RFC3339) + `"}`)
+	fs["/root/.master.staging-1/config.json"] = []byte("{}")
+	// root is not a run even with index
+	fs["/root/config.json"] = []byte("{}")
+	fs["/other/o1/config.json"] = []byte("{}")
+
+	cfg := RetentionConfig{
+		Root:        "/root",
+		KeepLast:    1,
+		KeepYounger: 24 * time.Hour,
+		Pinned:      []string{"master/m1"},
+	}
+
+	runs, err := storage.ListRuns(fs, cfg.Root)
+	require.NoError(t, err)
+	require.Len(t, runs, 7)
+	require.Equal(t, "root/master/m4", runs[0].Dir)
+
+	t.Run("dry run", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := Collect(fs, cfg, true, out)
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "would remove root/master/m2")
+		require.Contains(t, out.String(), "would remove root/master/m3")
+		require.Contains(t, out.String(), "would remove root/feature/f1")
+		require.Contains(t, out.String(), "keep root/master/m1")
+		require.Contains(t, out.String(), "keep root/locked")
+		require.Contains(t, fs, "/root/master/m2/config.json")
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := Collect(lockedRemoveFS{fs, t}, cfg, false, out)
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "removed 3 of 7 runs")
+		require.Contains(t, out.String(), "): locked by job-1")
+		require.NotContains(t, fs, "/root/master/m2.lock")
+		require.Contains(t, fs, "/root/locked.lock")
+		require.Contains(t, fs, "/root/config.json")
+
+		runs, err := storage.ListRuns(fs, cfg.Root)
+		require.NoError(t, err)
+		var dirs []string
+		for _, r := range runs {
+			dirs = append(dirs, r.Dir)
+		}
+		require.Equal(t, []string{"root/master/m4", "root/feature/f2", "root/master/m1", "root/locked"}, dirs)
+		require.Contains(t, fs, "/root/.master.staging-1/config.json")
+		require.Contains(t, fs, "/other/o1/config.json")
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := Config{
+		WebDav: middleware.WebDavConfig{Host: "test", Username: "user", Password: "pwd", Timeout: time.Minute},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "validation for 'Root' failed")
+
+	cfg.Retention.Root = "root"
+	err = cfg.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "keeplast or keepyounger")
+
+	cfg.Retention.KeepLast = 5
+	require.NoError(t, cfg.Validate())
+}
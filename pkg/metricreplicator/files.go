@@ -6,25 +6,74 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/studio-b12/gowebdav"
 
 	"github.com/insolar/consensus-reports/pkg/replicator"
+	"github.com/insolar/consensus-reports/pkg/storage"
 )
 
 const (
 	fileMode = 0644
+
+	stagingSuffix     = ".staging-"
+	defaultStagingTTL = time.Hour
 )
 
-// UploadFiles creates webdav client, makes remote directory and upload all files from tmp directory.
+func newWebdavClient(cfg replicator.LoaderConfig) *gowebdav.Client {
+	return storage.NewWebdavClient(cfg.URL, cfg.User, cfg.Password, cfg.Timeout).Client
+}
+
+// UploadFiles creates webdav client, uploads all files from tmp directory into staging remote directory
+// and moves it to remote directory in one step, so readers never see partial set of files.
+// Remote directory content is copied to staging directory first if cfg.KeepExisting is set,
+// otherwise existing remote directory is replaced only if cfg.Overwrite is set.
 func (repl Replicator) UploadFiles(ctx context.Context, cfg replicator.LoaderConfig, files []string) error {
-	client := gowebdav.NewClient(cfg.URL, cfg.User, cfg.Password)
-	client.SetTimeout(cfg.Timeout)
+	remoteDir := strings.Trim(cfg.RemoteDirName, "/")
+	if remoteDir == "" {
+		return errors.New("remote dir name is required")
+	}
+
+	client := newWebdavClient(cfg)
 
-	if err := client.Mkdir(cfg.RemoteDirName, fileMode); err != nil {
-		return errors.Wrap(err, "failed to create remote dir")
+	parentDir, dirName := path.Split(remoteDir)
+	ttl := cfg.StagingTTL
+	if ttl == 0 {
+		ttl = defaultStagingTTL
 	}
+	removeAbandonedStagingDirs(client, parentDir, dirName, ttl)
+
+	stagingDir := path.Join(parentDir, stagingDirName(dirName, time.Now()))
+	copied := false
+	if cfg.KeepExisting {
+		err := client.Copy(remoteDir, stagingDir, true)
+		if err != nil && !storage.IsNotFound(err) {
+			return errors.Wrap(err, "failed to copy remote dir to staging dir")
+		}
+		copied = err == nil
+	}
+
+	// copy creates staging dir, second MKCOL of existing collection fails with 405
+	if !copied {
+		if err := client.MkdirAll(stagingDir, fileMode); err != nil {
+			return errors.Wrap(err, "failed to create staging dir")
+		}
+	}
+
+	published := false
+	defer func() {
+		if published {
+			return
+		}
+		if err := client.RemoveAll(stagingDir); err != nil {
+			log.Printf("failed to remove staging dir %s: %v", stagingDir, err)
+		}
+	}()
 
 	for _, f := range files {
 		localFilePath := repl.TmpDir + "/" + f
@@ -33,14 +82,94 @@ func (repl Replicator) UploadFiles(ctx context.Context, cfg replicator.LoaderCon
 			return errors.Wrap(err, "failed to read local file")
 		}
 
-		remoteFilePath := cfg.RemoteDirName + "/" + f
+		remoteFilePath := stagingDir + "/" + f
 		if err := client.Write(remoteFilePath, data, fileMode); err != nil {
 			return errors.Wrap(err, "failed to write data to remote file")
 		}
 	}
+
+	// staging dir of append mode has all files of remote dir, other runs must not replace existing one
+	overwrite := cfg.KeepExisting || cfg.Overwrite
+	if err := client.Rename(stagingDir, remoteDir, overwrite); err != nil {
+		if storage.IsPreconditionFailed(err) {
+			return errors.Errorf("remote dir %s already exists", remoteDir)
+		}
+		return errors.Wrap(err, "failed to move staging dir to remote dir")
+	}
+	published = true
 	return nil
 }
 
+// stagingDirName returns hidden name of staging directory for dirName with creation time in it.
+func stagingDirName(dirName string, created time.Time) string {
+	return "." + dirName + stagingSuffix + strconv.FormatInt(created.Unix(), 10)
+}
+
+// removeAbandonedStagingDirs removes staging directories for dirName older than ttl.
+// They are left by replicators which died during upload. Errors are only logged,
+// because they must not prevent upload.
+func removeAbandonedStagingDirs(client *gowebdav.Client, parentDir, dirName string, ttl time.Duration) {
+	if parentDir == "" {
+		parentDir = "/"
+	}
+
+	entries, err := client.ReadDir(parentDir)
+	if err != nil {
+		log.Printf("failed to list staging dirs in %s: %v", parentDir, err)
+		return
+	}
+
+	prefix := "." + dirName + stagingSuffix
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+
+		created, err := strconv.ParseInt(strings.TrimPrefix(e.Name(), prefix), 10, 64)
+		if err != nil || time.Since(time.Unix(created, 0)) < ttl {
+			continue
+		}
+
+		log.Printf("removing abandoned staging dir %s", e.Name())
+		if err := client.RemoveAll(path.Join(parentDir, e.Name())); err != nil {
+			log.Printf("failed to remove abandoned staging dir %s: %v", e.Name(), err)
+		}
+	}
+}
+
+// DownloadConfigFile reads index file from remote directory.
+// Files list of indexes without one is restored from remote directory content.
+func (repl Replicator) DownloadConfigFile(ctx context.Context, cfg replicator.LoaderConfig, filename string) (replicator.OutputConfig, bool, error) {
+	client := newWebdavClient(cfg)
+
+	data, err := client.Read(cfg.RemoteDirName + "/" + filename)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return replicator.OutputConfig{}, false, nil
+		}
+		return replicator.OutputConfig{}, false, errors.Wrap(err, "failed to read remote config file")
+	}
+
+	var outputCfg replicator.OutputConfig
+	if err := json.Unmarshal(data, &outputCfg); err != nil {
+		return replicator.OutputConfig{}, false, errors.Wrap(err, "failed to unmarshal remote config file")
+	}
+
+	if len(outputCfg.Files) == 0 {
+		remoteFiles, err := client.ReadDir(cfg.RemoteDirName)
+		if err != nil {
+			return replicator.OutputConfig{}, false, errors.Wrap(err, "failed to read remote dir")
+		}
+		for _, f := range remoteFiles {
+			if f.IsDir() || f.Name() == filename || !strings.HasSuffix(f.Name(), ".json") {
+				continue
+			}
+			outputCfg.Files = append(outputCfg.Files, f.Name())
+		}
+	}
+	return outputCfg, true, nil
+}
+
 func (repl Replicator) saveDataToFile(data []byte, filename string) error {
 	filePath := repl.TmpDir + "/" + filename
 
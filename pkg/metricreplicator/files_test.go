@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -74,3 +75,164 @@ func TestReplicator_UploadFiles(t *testing.T) {
 	require.NoError(t, err)
 
 }
+
+func TestReplicator_DownloadConfigFile(t *testing.T) {
+	repl := Replicator{TmpDir: testTmpDir}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/existing/"+replicator.DefaultConfigFilename {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"charts":["sent_traffic"],"quantiles":["0.5"],"files":["network_size_5.json"]}`))
+	}))
+	defer ts.Close()
+
+	t.Run("existing", func(t *testing.T) {
+		loaderCfg := replicator.LoaderConfig{URL: ts.URL, RemoteDirName: "existing"}
+		cfg, ok, err := repl.DownloadConfigFile(context.Background(), loaderCfg, replicator.DefaultConfigFilename)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []string{"sent_traffic"}, cfg.Charts)
+		require.Equal(t, []string{"0.5"}, cfg.Quantiles)
+		require.Equal(t, []string{"network_size_5.json"}, cfg.Files)
+
+		merged := cfg.Merge(replicator.OutputConfig{
+			Charts:    []string{"sent_traffic", "phase2_duration"},
+			Quantiles: []string{"0.5", "0.8"},
+			Files:     []string{"network_size_10.json"},
+		})
+		require.Equal(t, []string{"sent_traffic", "phase2_duration"}, merged.Charts)
+		require.Equal(t, []string{"0.5", "0.8"}, merged.Quantiles)
+		require.Equal(t, []string{"network_size_5.json", "network_size_10.json"}, merged.Files)
+	})
+	t.Run("missing", func(t *testing.T) {
+		loaderCfg := replicator.LoaderConfig{URL: ts.URL, RemoteDirName: "missing"}
+		_, ok, err := repl.DownloadConfigFile(context.Background(), loaderCfg, replicator.DefaultConfigFilename)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
+
+// multistatusResponse returns PROPFIND response body listing collection with sub collections.
+func multistatusResponse(self string, collections ...string) string {
+	entry := func(href string) string {
+		return `<d:response><d:href>` + href + `</d:href><d:propstat><d:prop>` +
+			`<d:resourcetype><d:collection/></d:resourcetype></d:prop>` +
+			`<d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`
+	}
+	body := `<?xml version="1.0" encoding="utf-8"?><d:multistatus xmlns:d="DAV:">` + entry(self)
+	for _, c := range collections {
+		body += entry(self + c + "/")
+	}
+	return body + `</d:multistatus>`
+}
+
+func TestReplicator_UploadFilesStaging(t *testing.T) {
+	repl := Replicator{TmpDir: testTmpDir}
+
+	clean, err := MakeTmpDir(repl.TmpDir)
+	defer clean()
+	require.NoError(t, err, "failed to create tmp dir")
+
+	filename := replicator.DefaultConfigFilename
+	err = repl.MakeConfigFile(context.Background(), replicator.OutputConfig{}, filename)
+	require.NoError(t, err)
+
+	abandoned := stagingDirName("fake", time.Now().Add(-2*time.Hour))
+	fresh := stagingDirName("fake", time.Now().Add(-time.Minute))
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PROPFIND":
+			w.WriteHeader(http.StatusMultiStatus)
+			_, _ = w.Write([]byte(multistatusResponse("/runs/", abandoned, fresh, "other")))
+			return
+		case "MOVE":
+			requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Destination"))
+		default:
+			requests = append(requests, r.Method+" "+r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	loaderCfg := replicator.LoaderConfig{
+		URL:           ts.URL,
+		RemoteDirName: "runs/fake",
+	}
+	err = repl.UploadFiles(context.Background(), loaderCfg, []string{filename})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 4)
+	require.Equal(t, "DELETE /runs/"+abandoned, requests[0])
+	require.Regexp(t, `^MKCOL /runs/\.fake\.staging-\d+/$`, requests[1])
+	require.Regexp(t, `^PUT /runs/\.fake\.staging-\d+/config\.json$`, requests[2])
+	require.Regexp(t, `^MOVE /runs/\.fake\.staging-\d+ `+ts.URL+`/runs/fake$`, requests[3])
+
+	t.Run("append to existing dir", func(t *testing.T) {
+		requests := []string{}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Overwrite"))
+			switch r.Method {
+			case "PROPFIND":
+				w.WriteHeader(http.StatusMultiStatus)
+				_, _ = w.Write([]byte(multistatusResponse("/runs/", "fake")))
+			case "MKCOL":
+				// collection exists after copy
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			case "MOVE":
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusCreated)
+			}
+		}))
+		defer ts.Close()
+
+		loaderCfg := replicator.LoaderConfig{URL: ts.URL, RemoteDirName: "runs/fake", KeepExisting: true}
+		err := repl.UploadFiles(context.Background(), loaderCfg, []string{filename})
+		require.NoError(t, err)
+
+		require.Len(t, requests, 4)
+		require.Regexp(t, `^COPY /runs/fake `, requests[1])
+		require.Regexp(t, `^PUT /runs/\.fake\.staging-\d+/config\.json`, requests[2])
+		require.Regexp(t, `^MOVE /runs/\.fake\.staging-\d+ T$`, requests[3])
+	})
+
+	t.Run("existing dir", func(t *testing.T) {
+		var moves []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "PROPFIND":
+				w.WriteHeader(http.StatusMultiStatus)
+				_, _ = w.Write([]byte(multistatusResponse("/runs/", "fake")))
+			case "MOVE":
+				moves = append(moves, r.Header.Get("Overwrite"))
+				if r.Header.Get("Overwrite") == "F" {
+					w.WriteHeader(http.StatusPreconditionFailed)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusCreated)
+			}
+		}))
+		defer ts.Close()
+
+		loaderCfg := replicator.LoaderConfig{URL: ts.URL, RemoteDirName: "runs/fake"}
+		err := repl.UploadFiles(context.Background(), loaderCfg, []string{filename})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "remote dir runs/fake already exists")
+
+		loaderCfg.Overwrite = true
+		require.NoError(t, repl.UploadFiles(context.Background(), loaderCfg, []string{filename}))
+		require.Equal(t, []string{"F", "T"}, moves)
+	})
+
+	t.Run("without remote dir", func(t *testing.T) {
+		err = repl.UploadFiles(context.Background(), replicator.LoaderConfig{URL: ts.URL}, []string{filename})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "remote dir name is required")
+	})
+}
@@ -99,8 +99,8 @@ func (repl Replicator) grabRecord(ctx context.Context, query string, startTime,
 	return record, warnings, nil
 }
 
-// getFilename generates name from Period immutable and mutable properties.
-func getFilename(period replicator.PeriodInfo) string {
+// PeriodFilename generates name from Period immutable and mutable properties.
+func PeriodFilename(period replicator.PeriodInfo) string {
 	filename := ""
 
 	for _, p := range period.Network {
@@ -120,7 +120,8 @@ func getFilename(period replicator.PeriodInfo) string {
 	return filename
 }
 
-func (repl Replicator) GrabRecordsByPeriod(ctx context.Context, quantiles []string, period replicator.PeriodInfo) (string, error) {
+// GrabResult queries records of period without saving them.
+func (repl Replicator) GrabResult(ctx context.Context, quantiles []string, period replicator.PeriodInfo) (ResultData, error) {
 	var (
 		allWarns = make([]string, 0)
 		records  []RecordInfo
@@ -133,7 +134,7 @@ func (repl Replicator) GrabRecordsByPeriod(ctx context.Context, quantiles []stri
 
 				record, warnings, err := repl.grabRecord(ctx, query, period.Start, period.End, p, q)
 				if err != nil {
-					return "", errors.Wrap(err, "failed to grab record")
+					return ResultData{}, errors.Wrap(err, "failed to grab record")
 				}
 
 				allWarns = append(allWarns, warnings...)
@@ -143,16 +144,14 @@ func (repl Replicator) GrabRecordsByPeriod(ctx context.Context, quantiles []stri
 		}
 		record, warnings, err := repl.grabRecord(ctx, p.Formula, period.Start, period.End, p, "")
 		if err != nil {
-			return "", errors.Wrap(err, "failed to grab record")
+			return ResultData{}, errors.Wrap(err, "failed to grab record")
 		}
 
 		allWarns = append(allWarns, warnings...)
 		records = append(records, record)
 	}
 
-	filename := getFilename(period)
-
-	result := ResultData{
+	return ResultData{
 		Warnings:    allWarns,
 		Records:     records,
 		Properties:  toNetworkProperties(period.Properties),
@@ -160,8 +159,17 @@ func (repl Replicator) GrabRecordsByPeriod(ctx context.Context, quantiles []stri
 		Description: period.Description,
 		StartTime:   period.Start.UTC(),
 		EndTime:     period.End.UTC(),
+	}, nil
+}
+
+func (repl Replicator) GrabRecordsByPeriod(ctx context.Context, quantiles []string, period replicator.PeriodInfo) (string, error) {
+	result, err := repl.GrabResult(ctx, quantiles, period)
+	if err != nil {
+		return "", err
 	}
 
+	filename := PeriodFilename(period)
+
 	rawMsg, marshalErr := json.Marshal(result)
 	if marshalErr != nil {
 		return "", errors.Wrap(marshalErr, "failed to marshal result")
@@ -173,6 +181,15 @@ func (repl Replicator) GrabRecordsByPeriod(ctx context.Context, quantiles []stri
 	return filename, nil
 }
 
+// Charts returns names of grabbed charts.
+func (repl Replicator) Charts() []string {
+	var charts []string
+	for _, p := range repl.ConsensusProperties {
+		charts = append(charts, p.Name)
+	}
+	return charts
+}
+
 func (repl Replicator) GrabRecords(ctx context.Context, quantiles []string, periods []replicator.PeriodInfo) ([]string, []string, error) {
 	var files []string
 	for _, p := range periods {
@@ -183,9 +200,5 @@ func (repl Replicator) GrabRecords(ctx context.Context, quantiles []string, peri
 		files = append(files, filename)
 	}
 
-	var charts []string
-	for _, p := range repl.ConsensusProperties {
-		charts = append(charts, p.Name)
-	}
-	return files, charts, nil
+	return files, repl.Charts(), nil
 }
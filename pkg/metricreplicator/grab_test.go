@@ -65,6 +65,15 @@ func TestReplicator_GrabRecords(t *testing.T) {
 		require.Equal(t, []string{"latency_50ms_network_size_5.json", "network_size_10.json"}, files)
 		require.Equal(t, []string{"sent_traffic_per_node", "phase2_duration", "sent_traffic"}, charts)
 	})
+	t.Run("in memory", func(t *testing.T) {
+		result, err := repl.GrabResult(ctx, []string{"0.8"}, ranges[0])
+		require.NoError(t, err)
+		require.Len(t, result.Records, 3)
+		require.Equal(t, "phase2_duration", result.Records[1].Chart)
+		require.Equal(t, "0.8", result.Records[1].Quantile)
+		require.Equal(t, 10.0, result.Records[1].Value)
+		require.Equal(t, []NetworkProperty{{Name: "network_size", Value: "5"}}, result.Properties)
+	})
 	t.Run("query error", func(t *testing.T) {
 		params := []replicator.PeriodInfo{
 			{
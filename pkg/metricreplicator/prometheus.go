@@ -16,6 +16,15 @@ type Replicator struct {
 }
 
 func New(address, tmpDir string) (replicator.Replicator, error) {
+	return newReplicator(address, tmpDir)
+}
+
+// NewGrabber creates replicator which only grabs records to memory, e.g. for live report.
+func NewGrabber(address string) (Replicator, error) {
+	return newReplicator(address, "")
+}
+
+func newReplicator(address, tmpDir string) (Replicator, error) {
 	properties := []consensusProperty{
 		sentTrafficPerNode, sentTrafficOverall,
 		recvTrafficPerNode, recvTrafficOverall,
@@ -4,9 +4,11 @@ import (
 	"github.com/insolar/insconfig"
 	"github.com/pkg/errors"
 	"gopkg.in/go-playground/validator.v9"
+	"strings"
 	"time"
 
 	"github.com/insolar/consensus-reports/pkg/replicator"
+	"github.com/insolar/consensus-reports/pkg/storage"
 )
 
 type PropertyConfig struct {
@@ -26,6 +28,59 @@ type WebDavConfig struct {
 	Password  string        `mapstructure:"password" validate:"required" insconfigsecret:""`
 	Timeout   time.Duration `mapstructure:"timeout" validate:"required"`
 	Directory string        `directory:"host"`
+	// StagingTTL is age of abandoned staging directories to remove, default is 1h.
+	StagingTTL time.Duration `mapstructure:"stagingttl"`
+	// LockOwner is written to lock file of directory, default is user@host.
+	LockOwner string `mapstructure:"lockowner"`
+	// LockLease is time after which lock of died writer expires, default is 30m.
+	LockLease time.Duration `mapstructure:"locklease"`
+	// PathTemplate is remote directory template with {directory}, {branch}, {hash} and {date} placeholders,
+	// e.g. "{branch}/{date}/{hash}". Directory is used as is if template is empty.
+	PathTemplate string `mapstructure:"pathtemplate"`
+	// RunDate is {date} placeholder value in RunDateLayout, it is required if path template has {date}.
+	RunDate string `mapstructure:"rundate"`
+}
+
+const RunDateLayout = "2006-01-02"
+
+// RunDirectory returns remote directory of run resolved from PathTemplate.
+func (cfg WebDavConfig) RunDirectory(branch, hash string) (string, error) {
+	if cfg.PathTemplate == "" {
+		return cfg.Directory, nil
+	}
+
+	// current date differs for replicator and report started after midnight, so date is set explicitly
+	date := cfg.RunDate
+	if date == "" && strings.Contains(cfg.PathTemplate, "{date}") {
+		return "", errors.New("run date is required for {date} placeholder of path template")
+	}
+	if date != "" {
+		if _, err := time.Parse(RunDateLayout, date); err != nil {
+			return "", errors.Wrap(err, "failed to parse run date")
+		}
+	}
+
+	// base directory can be nested, git values are one level each
+	dir, err := storage.ResolvePath(cfg.PathTemplate, map[string]string{
+		"directory": cfg.Directory,
+		"branch":    storage.PathSegment(branch),
+		"hash":      storage.PathSegment(hash),
+		"date":      date,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve remote directory")
+	}
+	return dir, nil
+}
+
+// LockConfig returns config of directory lock.
+func (cfg WebDavConfig) LockConfig(breakLock bool) storage.LockConfig {
+	return storage.LockConfig{
+		Dir:   cfg.Directory,
+		Owner: cfg.LockOwner,
+		Lease: cfg.LockLease,
+		Break: breakLock,
+	}
 }
 
 type GroupConfig struct {
@@ -34,6 +89,15 @@ type GroupConfig struct {
 	Ranges      []RangeConfig    `mapstructure:"ranges" validate:"min=1,dive,required"`
 }
 
+// ComponentConfig is a repository of benchmarked build.
+type ComponentConfig struct {
+	Name string `mapstructure:"name" validate:"required"`
+	// Repo is a repository URL, {name} in it is replaced with component name, e.g. "https://github.com/insolar/{name}".
+	Repo   string `mapstructure:"repo"`
+	Branch string `mapstructure:"branch"`
+	Commit string `mapstructure:"commit"`
+}
+
 type PrometheusConfig struct {
 	Host string `mapstructure:"host" validate:"required"`
 }
@@ -47,7 +111,11 @@ type Config struct {
 	Git        struct {
 		Branch string
 		Hash   string
+		// CommitDate is a commit time of hash in RFC3339 format, e.g. output of `git log -1 --format=%cI`.
+		CommitDate string
 	}
+	// Components are repositories of build, they are saved to run metadata.
+	Components []ComponentConfig `mapstructure:"components" validate:"dive"`
 }
 
 type pathGetter struct {
@@ -81,9 +149,24 @@ func (cfg *Config) Validate() error {
 	if err := validate.Struct(cfg); err != nil {
 		return err
 	}
+	if _, err := cfg.CommitTime(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// CommitTime returns parsed commit date of git config, it is zero if date is not set.
+func (cfg Config) CommitTime() (time.Time, error) {
+	if cfg.Git.CommitDate == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(time.RFC3339, cfg.Git.CommitDate)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to parse commit date")
+	}
+	return date.UTC(), nil
+}
+
 func (cfg Config) LoaderConfig() replicator.LoaderConfig {
 	return replicator.LoaderConfig{
 		URL:           cfg.WebDav.Host,
@@ -91,6 +174,7 @@ func (cfg Config) LoaderConfig() replicator.LoaderConfig {
 		Password:      cfg.WebDav.Password,
 		RemoteDirName: cfg.WebDav.Directory,
 		Timeout:       cfg.WebDav.Timeout,
+		StagingTTL:    cfg.WebDav.StagingTTL,
 	}
 }
 
@@ -111,6 +195,23 @@ func GroupsToReplicatorPeriods(groups []GroupConfig) []replicator.PeriodInfo {
 	return props
 }
 
+// ToReplicatorComponents converts config of components to run metadata.
+func ToReplicatorComponents(components []ComponentConfig) []replicator.Component {
+	if len(components) == 0 {
+		return nil
+	}
+	result := make([]replicator.Component, 0, len(components))
+	for _, c := range components {
+		result = append(result, replicator.Component{
+			Name:   c.Name,
+			Repo:   c.Repo,
+			Branch: c.Branch,
+			Commit: c.Commit,
+		})
+	}
+	return result
+}
+
 func toPeriodProperties(props []PropertyConfig) []replicator.PeriodProperty {
 	replProps := make([]replicator.PeriodProperty, 0, len(props))
 	for _, p := range props {
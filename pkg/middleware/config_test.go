@@ -39,9 +39,10 @@ func TestConfig_Validate(t *testing.T) {
 				Timeout:  time.Minute,
 			},
 			Git: struct {
-				Branch string
-				Hash   string
-			}{"master", "hash"},
+				Branch     string
+				Hash       string
+				CommitDate string
+			}{"master", "hash", ""},
 		}
 		err := cfg.Validate()
 		require.NoError(t, err)
@@ -58,9 +59,10 @@ func TestConfig_Validate(t *testing.T) {
 				Password: "pwd",
 			},
 			Git: struct {
-				Branch string
-				Hash   string
-			}{"master", "hash"},
+				Branch     string
+				Hash       string
+				CommitDate string
+			}{"master", "hash", ""},
 		}
 		err := cfg.Validate()
 		require.Error(t, err)
@@ -89,9 +91,10 @@ func TestConfig_Validate(t *testing.T) {
 				Username: "user",
 			},
 			Git: struct {
-				Branch string
-				Hash   string
-			}{"master", "hash"},
+				Branch     string
+				Hash       string
+				CommitDate string
+			}{"master", "hash", ""},
 		}
 		err := cfg.Validate()
 		require.Error(t, err)
@@ -227,3 +230,61 @@ func TestGroupsToReplicatorPeriods(t *testing.T) {
 	periods := GroupsToReplicatorPeriods(groups)
 	require.Equal(t, expectedPeriods, periods)
 }
+
+func TestWebDavConfig_RunDirectory(t *testing.T) {
+	t.Run("without template", func(t *testing.T) {
+		cfg := WebDavConfig{Directory: "fake102"}
+		dir, err := cfg.RunDirectory("master", "977022b")
+		require.NoError(t, err)
+		require.Equal(t, "fake102", dir)
+	})
+	t.Run("template", func(t *testing.T) {
+		cfg := WebDavConfig{
+			Directory:    "consensus",
+			PathTemplate: "{directory}/{branch}/{date}/{hash}",
+			RunDate:      "2020-06-17",
+		}
+		dir, err := cfg.RunDirectory("master", "977022b")
+		require.NoError(t, err)
+		require.Equal(t, "consensus/master/2020-06-17/977022b", dir)
+	})
+	t.Run("nested directory", func(t *testing.T) {
+		cfg := WebDavConfig{Directory: "consensus/reports", PathTemplate: "{directory}/{branch}/{hash}"}
+		dir, err := cfg.RunDirectory("feature/network", "977022b")
+		require.NoError(t, err)
+		require.Equal(t, "consensus/reports/feature-network/977022b", dir)
+	})
+	t.Run("without date", func(t *testing.T) {
+		cfg := WebDavConfig{PathTemplate: "{date}"}
+		_, err := cfg.RunDirectory("master", "977022b")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "run date is required")
+	})
+	t.Run("wrong date", func(t *testing.T) {
+		cfg := WebDavConfig{PathTemplate: "{date}", RunDate: "17.06.2020"}
+		_, err := cfg.RunDirectory("master", "977022b")
+		require.Error(t, err)
+	})
+	t.Run("empty hash", func(t *testing.T) {
+		cfg := WebDavConfig{PathTemplate: "{branch}/{hash}"}
+		_, err := cfg.RunDirectory("master", "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "{hash}")
+	})
+}
+
+func TestConfig_CommitTime(t *testing.T) {
+	cfg := Config{}
+	date, err := cfg.CommitTime()
+	require.NoError(t, err)
+	require.True(t, date.IsZero())
+
+	cfg.Git.CommitDate = "2020-06-17T15:04:05+03:00"
+	date, err = cfg.CommitTime()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2020, 6, 17, 12, 4, 5, 0, time.UTC), date)
+
+	cfg.Git.CommitDate = "2020-06-17"
+	_, err = cfg.CommitTime()
+	require.Error(t, err)
+}
@@ -12,6 +12,9 @@ type Replicator interface {
 	GrabRecords(ctx context.Context, quantiles []string, periods []PeriodInfo) (files, charts []string, err error)
 	GrabRecordsByPeriod(ctx context.Context, quantiles []string, period PeriodInfo) (string, error)
 	UploadFiles(ctx context.Context, cfg LoaderConfig, files []string) error
+	// DownloadConfigFile reads OutputConfig json data from remote directory.
+	// Returns false if remote directory has no such file.
+	DownloadConfigFile(ctx context.Context, cfg LoaderConfig, filename string) (OutputConfig, bool, error)
 }
 
 type PeriodInfo struct {
@@ -24,8 +27,68 @@ type PeriodInfo struct {
 }
 
 type OutputConfig struct {
-	Charts    []string `json:"charts"`
-	Quantiles []string `json:"quantiles"`
+	Charts    []string     `json:"charts"`
+	Quantiles []string     `json:"quantiles"`
+	Files     []string     `json:"files"`
+	Run       *RunMetadata `json:"run,omitempty"`
+}
+
+// RunMetadata describes replicated run.
+type RunMetadata struct {
+	Branch string    `json:"branch"`
+	Hash   string    `json:"hash"`
+	Date   time.Time `json:"date"`
+	// CommitDate is a commit time of hash, it is zero if it is unknown.
+	CommitDate time.Time `json:"commit_date"`
+	// Components are repositories of benchmarked build, e.g. when it is built of several repositories.
+	Components []Component `json:"components,omitempty"`
+}
+
+// Component is a repository of benchmarked build.
+type Component struct {
+	Name string `json:"name"`
+	// Repo is a repository URL, {name} in it is replaced with component name.
+	Repo   string `json:"repo,omitempty"`
+	Branch string `json:"branch"`
+	Commit string `json:"commit"`
+}
+
+// Merge returns config with charts, quantiles and files from both configs without duplicates.
+// Values from cfg go first. Run metadata is taken from other if it is set.
+func (cfg OutputConfig) Merge(other OutputConfig) OutputConfig {
+	run := cfg.Run
+	if other.Run != nil {
+		run = other.Run
+	}
+	return OutputConfig{
+		Charts:    mergeLists(cfg.Charts, other.Charts),
+		Quantiles: mergeLists(cfg.Quantiles, other.Quantiles),
+		Files:     mergeLists(cfg.Files, other.Files),
+		Run:       run,
+	}
+}
+
+// RunInfo returns run metadata or empty one for indexes without it.
+func (cfg OutputConfig) RunInfo() RunMetadata {
+	if cfg.Run == nil {
+		return RunMetadata{}
+	}
+	return *cfg.Run
+}
+
+func mergeLists(first, second []string) []string {
+	seen := make(map[string]bool, len(first)+len(second))
+	result := make([]string, 0, len(first)+len(second))
+	for _, list := range [][]string{first, second} {
+		for _, v := range list {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
 }
 
 type PeriodProperty struct {
@@ -39,6 +102,12 @@ type LoaderConfig struct {
 	Password      string
 	RemoteDirName string
 	Timeout       time.Duration
+	// StagingTTL is age after which staging directories of failed uploads are removed.
+	StagingTTL time.Duration
+	// KeepExisting keeps files of remote directory which are not uploaded.
+	KeepExisting bool
+	// Overwrite replaces existing remote directory, upload fails on existing one without it.
+	Overwrite bool
 }
 
 const DefaultConfigFilename = "config.json"
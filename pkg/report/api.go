@@ -0,0 +1,149 @@
+package report
+
+import (
+	"encoding/json"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+)
+
+// DataFilter selects charts and quantiles, empty lists select everything.
+type DataFilter struct {
+	Charts    []string
+	Quantiles []string
+}
+
+func (f DataFilter) chart(name string) bool {
+	return len(f.Charts) == 0 || stringSet(f.Charts)[name]
+}
+
+func (f DataFilter) quantile(name string) bool {
+	return len(f.Quantiles) == 0 || stringSet(f.Quantiles)[name]
+}
+
+// ChartsJSON is a json of template data with charts aligned with x-axis.
+type ChartsJSON struct {
+	Branch     string              `json:"branch"`
+	Hash       string              `json:"hash"`
+	Directory  string              `json:"directory"`
+	Components []ComponentTemplate `json:"components,omitempty"`
+	XAxis      XAxis               `json:"xaxis"`
+	Charts     []ChartTemplate     `json:"charts"`
+	Warnings   []string            `json:"warnings"`
+	Comparison *Comparison         `json:"comparison,omitempty"`
+	Verdict    *Verdict            `json:"verdict,omitempty"`
+}
+
+// FilterCharts returns charts and series of data selected by filter, data is not changed.
+// Charts are matched by name or metric name of panels, comparison rows and rule checks
+// are filtered the same way, rules that can't be checked are kept.
+func (f DataFilter) FilterCharts(data *TemplateData) ChartsJSON {
+	result := ChartsJSON{
+		Branch:     data.GitBranch,
+		Hash:       data.GitCommitHash,
+		Components: data.Components,
+		Directory:  data.Directory,
+		XAxis:      data.xAxis,
+		Charts:     []ChartTemplate{},
+		Warnings:   append([]string{}, data.Warnings...),
+	}
+	selected := make(map[string]bool, len(data.ChartConfig))
+	for _, ct := range data.ChartConfig {
+		if !f.chart(ct.Name) && !f.chart(chartName(ct)) {
+			continue
+		}
+		selected[ct.Name] = true
+		filtered := ct
+		filtered.Series = make([]SeriesTemplate, 0, len(ct.Series))
+		for _, s := range ct.Series {
+			if f.quantile(s.Name) {
+				filtered.Series = append(filtered.Series, s)
+			}
+		}
+		filtered.Limits = nil
+		for _, l := range ct.Limits {
+			if f.quantile(l.Quantile) {
+				filtered.Limits = append(filtered.Limits, l)
+			}
+		}
+		result.Charts = append(result.Charts, filtered)
+	}
+
+	if data.Comparison != nil {
+		cmp := *data.Comparison
+		cmp.Rows = make([]DeltaRow, 0, len(data.Comparison.Rows))
+		for _, row := range data.Comparison.Rows {
+			if selected[row.Chart] && f.quantile(row.Quantile) {
+				cmp.Rows = append(cmp.Rows, row)
+			}
+		}
+		result.Comparison = &cmp
+	}
+	if data.Verdict != nil {
+		verdict := *data.Verdict
+		verdict.Checks = make([]RuleCheck, 0, len(data.Verdict.Checks))
+		for _, c := range data.Verdict.Checks {
+			if selected[c.Chart] && f.quantile(c.Quantile) {
+				verdict.Checks = append(verdict.Checks, c)
+			}
+		}
+		result.Verdict = &verdict
+	}
+	return result
+}
+
+// RawFile is a data file of run as it is uploaded by replicator.
+type RawFile struct {
+	Filename string         `json:"filename"`
+	Data     MetricFileJSON `json:"data"`
+}
+
+// FilterFiles returns files with records selected by filter, files are not changed.
+func (f DataFilter) FilterFiles(files []RawFile) []RawFile {
+	result := make([]RawFile, 0, len(files))
+	for _, file := range files {
+		filtered := file
+		filtered.Data.Records = make([]metricreplicator.RecordInfo, 0, len(file.Data.Records))
+		for _, r := range file.Data.Records {
+			if f.chart(r.Chart) && f.quantile(r.Quantile) {
+				filtered.Data.Records = append(filtered.Data.Records, r)
+			}
+		}
+		result = append(result, filtered)
+	}
+	return result
+}
+
+// RawFiles reads data files of run.
+func (b Browser) RawFiles(name string) ([]RawFile, error) {
+	dir, err := b.RunDirectory(name)
+	if err != nil {
+		return nil, err
+	}
+	client := b.client.ForDirectory(dir)
+
+	reportCfg, err := client.readConfigJSON()
+	if err != nil {
+		return nil, err
+	}
+	filenames, err := client.scanWebdavFiles(reportCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]RawFile, 0, len(filenames))
+	for _, filename := range filenames {
+		buf, err := client.fs.Read(path.Join(dir, filename))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", filename)
+		}
+		file := RawFile{Filename: filename}
+		if err := json.Unmarshal(buf, &file.Data); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", filename)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
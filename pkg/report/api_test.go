@@ -0,0 +1,88 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+func TestDataFilter_FilterCharts(t *testing.T) {
+	data := &TemplateData{
+		GitBranch: "master",
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(1)}},
+				{Name: "0.99", Data: []*float64{float(2)}},
+			}},
+			{Name: "phase3_duration_5", Metric: "phase3_duration", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(3)}},
+			}},
+			{Name: "consensus_duration", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(4)}},
+			}},
+		},
+		xAxis: XAxis{Name: "Nodes count", Data: []string{"5"}},
+		Comparison: &Comparison{Baseline: "master", Rows: []DeltaRow{
+			{Chart: "phase2_duration", Quantile: "0.5", X: "5", Regression: true},
+			{Chart: "phase2_duration", Quantile: "0.99", X: "5", Regression: true},
+			{Chart: "phase3_duration_5", Quantile: "0.5", X: "5"},
+			{Chart: "consensus_duration", Quantile: "0.5", X: "5", Regression: true},
+		}},
+		Verdict: &Verdict{
+			Checks: []RuleCheck{
+				{Chart: "phase2_duration", Quantile: "0.99", X: "5", Passed: false},
+				{Chart: "phase3_duration_5", Quantile: "0.5", X: "5", Passed: true},
+				{Chart: "consensus_duration", Quantile: "0.5", X: "5", Passed: false},
+			},
+			Errors: []string{"rule 3: no series of chart unknown"},
+		},
+	}
+
+	result := DataFilter{Charts: []string{"phase2_duration", "phase3_duration"}, Quantiles: []string{"0.5"}}.FilterCharts(data)
+	require.Equal(t, "master", result.Branch)
+	require.Equal(t, data.xAxis, result.XAxis)
+	require.Len(t, result.Charts, 2)
+	require.Equal(t, "phase2_duration", result.Charts[0].Name)
+	require.Equal(t, []SeriesTemplate{{Name: "0.5", Data: []*float64{float(1)}}}, result.Charts[0].Series)
+	require.Equal(t, "phase3_duration_5", result.Charts[1].Name)
+	require.Equal(t, "master", result.Comparison.Baseline)
+	require.Len(t, result.Comparison.Rows, 2)
+	require.Equal(t, 1, result.Comparison.Regressions())
+	require.Len(t, result.Verdict.Checks, 1)
+	require.Equal(t, "phase3_duration_5", result.Verdict.Checks[0].Chart)
+	require.Equal(t, data.Verdict.Errors, result.Verdict.Errors)
+
+	// data is not changed
+	require.Len(t, data.ChartConfig[0].Series, 2)
+	require.Len(t, data.Comparison.Rows, 4)
+	require.Len(t, data.Verdict.Checks, 3)
+
+	result = DataFilter{}.FilterCharts(data)
+	require.Len(t, result.Charts, 3)
+	require.Len(t, result.Comparison.Rows, 4)
+	require.Len(t, result.Verdict.Checks, 3)
+}
+
+func TestBrowser_RawFiles(t *testing.T) {
+	fs := memFS{}
+	writeRun(t, fs, "consensus/master/aaaaaaaaaa", replicator.RunMetadata{Branch: "master", Hash: "aaaaaaaaaa"}, 100)
+
+	files, err := newTestClient(fs, "").Browser("consensus", nil).RawFiles("master/aaaaaaaaaa")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "network_size_5.json", files[0].Filename)
+	require.Len(t, files[0].Data.Records, 2)
+
+	filtered := DataFilter{Charts: []string{"phase3_duration"}}.FilterFiles(files)
+	require.Len(t, filtered, 2)
+	require.Equal(t, []metricreplicator.RecordInfo{
+		{Chart: "phase3_duration", Unit: "ms", Quantile: "0.5", Value: 100},
+	}, filtered[1].Data.Records)
+	require.Len(t, files[1].Data.Records, 2)
+
+	_, err = newTestClient(fs, "").Browser("consensus", nil).RawFiles("master/unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package report
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/markbates/pkger"
+	"github.com/pkg/errors"
+)
+
+// ChartLibraryURL is a CDN link to the chart library used when it is not inlined.
+const ChartLibraryURL = "https://cdnjs.cloudflare.com/ajax/libs/echarts/4.8.0/echarts-en.min.js"
+
+// chartLibraryFile is a committed copy of ChartLibraryURL embedded by pkger like template.html,
+// `make assets` downloads it again when the url changes.
+var chartLibraryFile = pkger.Include("/pkg/report/assets/echarts-en.min.js")
+
+// chartLibraryBundled checks that chart library is embedded into the binary.
+func chartLibraryBundled() bool {
+	_, err := pkger.Stat(chartLibraryFile)
+	return err == nil
+}
+
+// chartLibrary returns bundled chart library script ready to be inlined into html.
+func chartLibrary() (string, error) {
+	f, err := pkger.Open(chartLibraryFile)
+	if os.IsNotExist(err) {
+		return "", errors.New("chart library is not bundled")
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open bundled chart library")
+	}
+	defer f.Close()
+
+	buf, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read bundled chart library")
+	}
+
+	// closing tag inside of script would end it
+	return strings.ReplaceAll(string(buf), "</script", `<\/script`), nil
+}
@@ -0,0 +1,121 @@
+package report
+
+import (
+	"encoding/json"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/storage"
+)
+
+// DefaultVerdictFileName is a verdict of checked report uploaded next to it.
+const DefaultVerdictFileName = "verdict.json"
+
+// RunSummary is a run under browsing root with metadata from its index.
+type RunSummary struct {
+	// Name is a run directory relative to root, it identifies run in urls.
+	Name       string    `json:"name"`
+	Branch     string    `json:"branch"`
+	Hash       string    `json:"hash"`
+	Date       time.Time `json:"date"`
+	CommitDate time.Time `json:"commit_date"`
+	// Verdict is a status of uploaded verdict, it is empty for runs without checks.
+	Verdict string `json:"verdict,omitempty"`
+}
+
+// Browser reads runs under root directory, they are checked with rules if they are set.
+type Browser struct {
+	client *WebdavClient
+	root   string
+	rules  *Rules
+}
+
+// Browser returns browser of runs under root.
+func (w *WebdavClient) Browser(root string, rules *Rules) Browser {
+	return Browser{client: w, root: strings.Trim(root, "/"), rules: rules}
+}
+
+// ListRuns returns runs under root, newest first.
+func (b Browser) ListRuns() ([]RunSummary, error) {
+	runs, err := storage.ListRuns(b.client.fs, b.root)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list runs")
+	}
+
+	result := make([]RunSummary, 0, len(runs))
+	for _, run := range runs {
+		info := run.Index.RunInfo()
+		result = append(result, RunSummary{
+			Name:       run.Name(b.root),
+			Branch:     info.Branch,
+			Hash:       info.Hash,
+			Date:       run.Date(),
+			CommitDate: run.CommitDate(),
+			Verdict:    b.readVerdict(run.Dir),
+		})
+	}
+	return result, nil
+}
+
+// readVerdict returns status of uploaded verdict, broken verdicts are logged and ignored.
+func (b Browser) readVerdict(dir string) string {
+	buf, err := b.client.fs.Read(path.Join("/", dir, DefaultVerdictFileName))
+	if err != nil {
+		if !storage.IsNotFound(err) {
+			log.Printf("failed to read verdict of %s: %v", dir, err)
+		}
+		return ""
+	}
+	var result CheckResult
+	if err := json.Unmarshal(buf, &result); err != nil {
+		log.Printf("failed to unmarshal verdict of %s: %v", dir, err)
+		return ""
+	}
+	return result.Status
+}
+
+// RunDirectory returns directory of run name, names can't point out of root.
+func (b Browser) RunDirectory(name string) (string, error) {
+	name = strings.Trim(path.Clean("/"+name), "/")
+	if name == "" {
+		return "", errors.New("run name is required")
+	}
+	return path.Join("/", b.root, name), nil
+}
+
+// RunReader returns reader of run report.
+func (b Browser) RunReader(name string) (TemplateDataReader, error) {
+	dir, err := b.RunDirectory(name)
+	if err != nil {
+		return nil, err
+	}
+	return b.withRules(b.client.ForDirectory(dir)), nil
+}
+
+// CompareReader returns reader of comparison report of two runs.
+func (b Browser) CompareReader(baseline, candidate string) (TemplateDataReader, error) {
+	baseDir, err := b.RunDirectory(baseline)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid baseline")
+	}
+	candDir, err := b.RunDirectory(candidate)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid candidate")
+	}
+	return b.withRules(CompareReader{
+		Baseline:  b.client.ForDirectory(baseDir),
+		Candidate: b.client.ForDirectory(candDir),
+		Tolerance: b.client.cfg.Compare.Tolerance,
+	}), nil
+}
+
+func (b Browser) withRules(reader TemplateDataReader) TemplateDataReader {
+	if b.rules == nil {
+		return reader
+	}
+	return RulesReader{Reader: reader, Rules: *b.rules}
+}
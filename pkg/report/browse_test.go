@@ -0,0 +1,77 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+func TestBrowser_ListRuns(t *testing.T) {
+	day := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	fs := memFS{}
+	writeRun(t, fs, "consensus/master/aaaaaaaaaa", replicator.RunMetadata{
+		Branch: "master", Hash: "aaaaaaaaaa", Date: day, CommitDate: day,
+	}, 100)
+	writeRun(t, fs, "consensus/feature/bbbbbbbbbb", replicator.RunMetadata{
+		Branch: "feature", Hash: "bbbbbbbbbb", Date: day.Add(time.Hour),
+	}, 200)
+	fs["/consensus/master/aaaaaaaaaa/verdict.json"] = []byte(`{"status": "regression"}`)
+
+	browser := newTestClient(fs, "").Browser("/consensus/", nil)
+	runs, err := browser.ListRuns()
+	require.NoError(t, err)
+	require.Equal(t, []RunSummary{
+		{Name: "feature/bbbbbbbbbb", Branch: "feature", Hash: "bbbbbbbbbb", Date: day.Add(time.Hour), CommitDate: day.Add(time.Hour)},
+		{Name: "master/aaaaaaaaaa", Branch: "master", Hash: "aaaaaaaaaa", Date: day, CommitDate: day, Verdict: CheckStatusRegression},
+	}, runs)
+
+	// verdict is not a data file of indexes without files list
+	files, err := newTestClient(fs, "/consensus/master/aaaaaaaaaa").scanWebdavFiles(&ConfigFileJSON{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"network_size_17.json", "network_size_5.json"}, files)
+}
+
+func TestBrowser_RunDirectory(t *testing.T) {
+	browser := newTestClient(memFS{}, "").Browser("consensus", nil)
+
+	dir, err := browser.RunDirectory("master/aabbcc/")
+	require.NoError(t, err)
+	require.Equal(t, "/consensus/master/aabbcc", dir)
+
+	// names can't point out of root
+	dir, err = browser.RunDirectory("../../secret")
+	require.NoError(t, err)
+	require.Equal(t, "/consensus/secret", dir)
+
+	_, err = browser.RunDirectory("/")
+	require.Error(t, err)
+}
+
+func TestBrowser_CompareReader(t *testing.T) {
+	fs := memFS{}
+	writeRun(t, fs, "consensus/master/aaaaaaaaaa", replicator.RunMetadata{Branch: "master", Hash: "aaaaaaaaaa"}, 100)
+	writeRun(t, fs, "consensus/feature/bbbbbbbbbb", replicator.RunMetadata{Branch: "feature", Hash: "bbbbbbbbbb"}, 200)
+
+	rules := &Rules{Rules: []Rule{{Chart: "phase2_duration", Max: 150}}}
+	browser := newTestClient(fs, "").Browser("consensus", rules)
+
+	reader, err := browser.RunReader("feature/bbbbbbbbbb")
+	require.NoError(t, err)
+	data, err := reader.ReadTemplateData()
+	require.NoError(t, err)
+	require.Equal(t, "feature", data.GitBranch)
+	require.NotNil(t, data.Verdict)
+	require.False(t, data.Verdict.Passed())
+
+	reader, err = browser.CompareReader("master/aaaaaaaaaa", "feature/bbbbbbbbbb")
+	require.NoError(t, err)
+	data, err = reader.ReadTemplateData()
+	require.NoError(t, err)
+	require.NotNil(t, data.Comparison)
+	// phase2 and phase3 at 17 nodes
+	require.Equal(t, 2, data.Comparison.Regressions())
+	require.NotNil(t, data.Verdict)
+}
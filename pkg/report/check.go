@@ -0,0 +1,167 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	CheckStatusPass       = "pass"
+	CheckStatusRegression = "regression"
+	CheckStatusError      = "error"
+)
+
+// Exit codes of check mode.
+const (
+	ExitCodePass       = 0
+	ExitCodeRegression = 1
+	ExitCodeDataError  = 2
+)
+
+// CheckResult is a machine-readable verdict of threshold rules and baseline comparison.
+type CheckResult struct {
+	Status   string `json:"status"`
+	Branch   string `json:"branch,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+	Baseline string `json:"baseline,omitempty"`
+	// Checks is a count of checked values.
+	Checks      int         `json:"checks"`
+	Failures    []RuleCheck `json:"failures"`
+	Regressions []DeltaRow  `json:"regressions"`
+	// Errors are problems which don't allow to check data, e.g. rules without series.
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+// ExitCode returns process exit code for status.
+func (r CheckResult) ExitCode() int {
+	switch r.Status {
+	case CheckStatusPass:
+		return ExitCodePass
+	case CheckStatusRegression:
+		return ExitCodeRegression
+	}
+	return ExitCodeDataError
+}
+
+// CheckError returns result for data which can't be read.
+func CheckError(err error) CheckResult {
+	return CheckResult{Status: CheckStatusError, Errors: []string{err.Error()}}
+}
+
+// Check evaluates verdict of rules and comparison with baseline of template data.
+func Check(data *TemplateData) CheckResult {
+	result := CheckResult{
+		Branch:      data.GitBranch,
+		Hash:        data.GitCommitHash,
+		Failures:    []RuleCheck{},
+		Regressions: []DeltaRow{},
+		Errors:      []string{},
+		Warnings:    append([]string{}, data.Warnings...),
+	}
+
+	if data.Verdict == nil && data.Comparison == nil {
+		result.Errors = append(result.Errors, "nothing to check, set threshold rules or baseline")
+	}
+
+	if v := data.Verdict; v != nil {
+		result.Checks += len(v.Checks)
+		result.Failures = append(result.Failures, v.Failures()...)
+		result.Errors = append(result.Errors, v.Errors...)
+	}
+
+	if c := data.Comparison; c != nil {
+		result.Baseline = c.Baseline
+		compared := 0
+		for _, row := range c.Rows {
+			if row.Delta == nil {
+				continue
+			}
+			compared++
+			if row.Regression {
+				result.Regressions = append(result.Regressions, row)
+			}
+		}
+		if compared == 0 {
+			result.Errors = append(result.Errors, "no values to compare with baseline "+c.Baseline)
+		}
+		result.Checks += compared
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		result.Status = CheckStatusError
+	case len(result.Failures) > 0 || len(result.Regressions) > 0:
+		result.Status = CheckStatusRegression
+	default:
+		result.Status = CheckStatusPass
+	}
+	return result
+}
+
+// WriteSummary prints short human-readable verdict.
+func (r CheckResult) WriteSummary(w io.Writer) error {
+	name := r.Branch
+	if r.Hash != "" {
+		name += "@" + r.Hash
+	}
+	if _, err := fmt.Fprintf(w, "%s: %s, %d checks\n", name, r.Status, r.Checks); err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, e := range r.Errors {
+		lines = append(lines, "error: "+e)
+	}
+	for _, f := range r.Failures {
+		lines = append(lines, fmt.Sprintf("limit exceeded: %s: %s > %s",
+			pointLabel(f.Chart, f.Quantile, f.Group, f.X), f.ValueText(), f.LimitText()))
+	}
+	for _, d := range r.Regressions {
+		lines = append(lines, fmt.Sprintf("regression vs %s: %s: %s -> %s (%s)",
+			r.Baseline, pointLabel(d.Chart, d.Quantile, d.Group, d.X), d.BaselineText(), d.CandidateText(), d.PercentText()))
+	}
+	if len(r.Warnings) > 0 {
+		lines = append(lines, fmt.Sprintf("%d data warnings, see verdict file", len(r.Warnings)))
+	}
+
+	for _, l := range lines {
+		if _, err := fmt.Fprintln(w, "  "+l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JSON returns result as indented json.
+func (r CheckResult) JSON() ([]byte, error) {
+	buf, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal verdict")
+	}
+	return buf, nil
+}
+
+// WriteFile saves result as json.
+func (r CheckResult) WriteFile(filename string) error {
+	buf, err := r.JSON()
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filename, buf, 0644); err != nil {
+		return errors.Wrap(err, "failed to write verdict file")
+	}
+	return nil
+}
+
+func pointLabel(chart, quantile, group, x string) string {
+	label := chart + quantileLabel(quantile)
+	if group != "" {
+		label += ", " + group
+	}
+	return label + ", x " + x
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	data := &TemplateData{GitBranch: "feature", GitCommitHash: "aabbcc"}
+	result := Check(data)
+	require.Equal(t, CheckStatusError, result.Status)
+	require.Equal(t, ExitCodeDataError, result.ExitCode())
+
+	data.Verdict = &Verdict{Checks: []RuleCheck{{Chart: "phase2_duration", Quantile: "0.99", X: "5", Value: 1, Limit: 2, Passed: true}}}
+	result = Check(data)
+	require.Equal(t, CheckStatusPass, result.Status)
+	require.Equal(t, ExitCodePass, result.ExitCode())
+	require.Equal(t, 1, result.Checks)
+
+	data.Comparison = &Comparison{Baseline: "master", Rows: []DeltaRow{
+		{Chart: "phase2_duration", Quantile: "0.99", X: "5", Baseline: float(10), Candidate: float(20), Delta: float(10), Percent: float(100), Regression: true},
+		{Chart: "phase2_duration", Quantile: "0.99", X: "10", Candidate: float(20)},
+	}}
+	result = Check(data)
+	require.Equal(t, CheckStatusRegression, result.Status)
+	require.Equal(t, ExitCodeRegression, result.ExitCode())
+	require.Equal(t, 2, result.Checks)
+	require.Len(t, result.Regressions, 1)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, result.WriteSummary(out))
+	require.Equal(t, "feature@aabbcc: regression, 2 checks\n"+
+		"  regression vs master: phase2_duration quantile 0.99, x 5: 10.00 -> 20.00 (+100.00%)\n", out.String())
+
+	data.Verdict.Errors = []string{"rule 2: no series of chart unknown"}
+	require.Equal(t, ExitCodeDataError, Check(data).ExitCode())
+}
+
+func TestCheckResult_WriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "verdict")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, "verdict.json")
+
+	result := CheckError(errors.New("failed to read"))
+	require.NoError(t, result.WriteFile(filename))
+
+	buf, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	var saved map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf, &saved))
+	require.Equal(t, "error", saved["status"])
+	require.Equal(t, []interface{}{"failed to read"}, saved["errors"])
+}
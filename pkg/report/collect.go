@@ -0,0 +1,128 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+)
+
+// recordKey identifies record of data file.
+type recordKey struct {
+	chart    string
+	quantile string
+}
+
+type chartInfo struct {
+	name        string
+	description string
+	unit        string
+	quantiles   []string
+}
+
+// warnings collects unique report warnings in order of appearance.
+type warnings struct {
+	list []string
+	seen map[string]bool
+}
+
+func (w *warnings) add(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if w.seen == nil {
+		w.seen = make(map[string]bool)
+	}
+	if w.seen[msg] {
+		return
+	}
+	w.seen[msg] = true
+	w.list = append(w.list, msg)
+}
+
+// recordValues returns values of file records by chart and quantile.
+func recordValues(f dataFile, warns *warnings) map[recordKey]float64 {
+	values := make(map[recordKey]float64, len(f.data.Records))
+	for _, r := range f.data.Records {
+		key := recordKey{chart: r.Chart, quantile: r.Quantile}
+		if _, ok := values[key]; ok {
+			warns.add("file %s has several records for chart %s%s, the first one is used", f.filename, r.Chart, quantileLabel(r.Quantile))
+			continue
+		}
+		values[key] = r.Value
+	}
+	return values
+}
+
+// collectCharts returns charts in order of index with all quantiles found in files.
+// Quantiles go in order of index, unknown ones are sorted after them.
+func collectCharts(files []dataFile, reportCfg *ConfigFileJSON, warns *warnings) []chartInfo {
+	charts := make(map[string]*chartInfo)
+	quantiles := make(map[string]map[string]bool)
+	order := make([]string, 0, len(reportCfg.ChartNames))
+
+	addChart := func(name string) *chartInfo {
+		ci := &chartInfo{name: name}
+		charts[name] = ci
+		quantiles[name] = make(map[string]bool)
+		order = append(order, name)
+		return ci
+	}
+	for _, name := range reportCfg.ChartNames {
+		if charts[name] == nil {
+			addChart(name)
+		}
+	}
+
+	for _, f := range files {
+		for _, r := range f.data.Records {
+			ci := charts[r.Chart]
+			if ci == nil {
+				warns.add("chart %s from %s is not in index", r.Chart, f.filename)
+				ci = addChart(r.Chart)
+			}
+
+			if ci.description == "" && ci.unit == "" {
+				ci.description, ci.unit = r.Description, r.Unit
+			} else if ci.unit != r.Unit {
+				warns.add("chart %s has unit %s in %s, but %s is used", r.Chart, r.Unit, f.filename, ci.unit)
+			}
+			quantiles[r.Chart][r.Quantile] = true
+		}
+	}
+
+	quantileOrder := make(map[string]int, len(reportCfg.Quantiles))
+	for i, q := range reportCfg.Quantiles {
+		quantileOrder[q] = i + 1
+	}
+
+	result := make([]chartInfo, 0, len(order))
+	for _, name := range order {
+		ci := charts[name]
+		if len(quantiles[name]) == 0 {
+			warns.add("chart %s has no records", name)
+			continue
+		}
+
+		for q := range quantiles[name] {
+			ci.quantiles = append(ci.quantiles, q)
+		}
+		sort.Slice(ci.quantiles, func(i, j int) bool {
+			qi, qj := ci.quantiles[i], ci.quantiles[j]
+			oi, oj := quantileOrder[qi], quantileOrder[qj]
+			switch {
+			case oi != 0 && oj != 0:
+				return oi < oj
+			case oi != 0 || oj != 0:
+				return oi != 0
+			default:
+				return qi < qj
+			}
+		})
+		result = append(result, *ci)
+	}
+	return result
+}
+
+func quantileLabel(q string) string {
+	if q == "" {
+		return ""
+	}
+	return " quantile " + q
+}
@@ -0,0 +1,280 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	SeriesRunBaseline  = "baseline"
+	SeriesRunCandidate = "candidate"
+)
+
+// CompareConfig sets baseline run to compare report directory with.
+type CompareConfig struct {
+	// Baseline is a directory of baseline run, comparison is off if it is empty.
+	Baseline string `mapstructure:"baseline"`
+	// Tolerance is allowed growth of candidate values in percents, it is 0 by default,
+	// so any growth is a regression. Bundled config files set it to 5.
+	Tolerance float64 `mapstructure:"tolerance"`
+}
+
+// Comparison is a table of differences between baseline and candidate runs.
+type Comparison struct {
+	Baseline  string     `json:"baseline"`
+	Candidate string     `json:"candidate"`
+	Tolerance float64    `json:"tolerance"`
+	Rows      []DeltaRow `json:"rows"`
+}
+
+// Regressions returns count of rows with regression.
+func (c Comparison) Regressions() int {
+	count := 0
+	for _, r := range c.Rows {
+		if r.Regression {
+			count++
+		}
+	}
+	return count
+}
+
+// DeltaRow is a difference of chart values for one quantile and x-axis value.
+// Values grow when consensus gets worse, so growth beyond tolerance is a regression.
+type DeltaRow struct {
+	Chart       string   `json:"chart"`
+	Description string   `json:"description,omitempty"`
+	Unit        string   `json:"unit,omitempty"`
+	Quantile    string   `json:"quantile"`
+	Group       string   `json:"series,omitempty"`
+	X           string   `json:"x"`
+	Baseline    *float64 `json:"baseline"`
+	Candidate   *float64 `json:"candidate"`
+	// Delta is nil if one of values is missing.
+	Delta *float64 `json:"delta"`
+	// Percent is nil if delta is missing or baseline is zero, growth from zero is a regression.
+	Percent     *float64 `json:"percent"`
+	Regression  bool     `json:"regression"`
+	Improvement bool     `json:"improvement"`
+}
+
+func formatValue(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*v, 'f', 2, 64)
+}
+
+func (r DeltaRow) BaselineText() string  { return formatValue(r.Baseline) }
+func (r DeltaRow) CandidateText() string { return formatValue(r.Candidate) }
+func (r DeltaRow) DeltaText() string     { return formatValue(r.Delta) }
+
+func (r DeltaRow) PercentText() string {
+	if r.Percent == nil {
+		switch {
+		case r.Regression:
+			return "+inf%"
+		case r.Improvement:
+			return "-inf%"
+		}
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f%%", *r.Percent)
+}
+
+// CompareReader reads template data of two runs and merges them into one report.
+type CompareReader struct {
+	Baseline  TemplateDataReader
+	Candidate TemplateDataReader
+	Tolerance float64
+}
+
+func (r CompareReader) ReadTemplateData() (*TemplateData, error) {
+	baseline, err := r.Baseline.ReadTemplateData()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read baseline")
+	}
+	candidate, err := r.Candidate.ReadTemplateData()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read candidate")
+	}
+	return Compare(baseline, candidate, r.Tolerance), nil
+}
+
+type seriesKey struct {
+	quantile string
+	group    string
+}
+
+// Compare returns candidate report with baseline series on the same charts and comparison table.
+func Compare(baseline, candidate *TemplateData, tolerance float64) *TemplateData {
+	result := *candidate
+	result.ChartConfig = make([]ChartTemplate, 0, len(candidate.ChartConfig))
+	result.xAxis = mergeXAxis(candidate.xAxis, baseline.xAxis)
+	result.Comparison = &Comparison{
+		Baseline:  baseline.RunName(),
+		Candidate: candidate.RunName(),
+		Tolerance: tolerance,
+	}
+
+	result.Warnings = append([]string{}, candidate.Warnings...)
+	for _, w := range baseline.Warnings {
+		result.Warnings = append(result.Warnings, "baseline: "+w)
+	}
+
+	baselineCharts := make(map[string]ChartTemplate, len(baseline.ChartConfig))
+	for _, ct := range baseline.ChartConfig {
+		baselineCharts[ct.Name] = ct
+	}
+
+	used := make(map[string]bool, len(candidate.ChartConfig))
+	for _, ct := range candidate.ChartConfig {
+		used[ct.Name] = true
+		base, ok := baselineCharts[ct.Name]
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("chart %s is missing in baseline", ct.Name))
+		}
+		merged, rows := compareChart(base, baseline.xAxis, ct, candidate.xAxis, result.xAxis, tolerance)
+		result.ChartConfig = append(result.ChartConfig, merged)
+		result.Comparison.Rows = append(result.Comparison.Rows, rows...)
+	}
+	for _, base := range baseline.ChartConfig {
+		if used[base.Name] {
+			continue
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("chart %s is missing in candidate", base.Name))
+		merged, rows := compareChart(base, baseline.xAxis, ChartTemplate{}, candidate.xAxis, result.xAxis, tolerance)
+		result.ChartConfig = append(result.ChartConfig, merged)
+		result.Comparison.Rows = append(result.Comparison.Rows, rows...)
+	}
+
+	return &result
+}
+
+// compareChart aligns series of both charts with x-axis and returns chart with all of them and deltas.
+func compareChart(base ChartTemplate, baseAxis XAxis, cand ChartTemplate, candAxis XAxis, axis XAxis, tolerance float64) (ChartTemplate, []DeltaRow) {
+	merged := cand
+	if merged.Name == "" {
+		merged = base
+	}
+	merged.Series = nil
+
+	baseSeries := alignSeries(base.Series, baseAxis, axis)
+	candSeries := alignSeries(cand.Series, candAxis, axis)
+
+	keys := make([]seriesKey, 0, len(cand.Series)+len(base.Series))
+	seen := make(map[seriesKey]bool)
+	for _, list := range [][]SeriesTemplate{cand.Series, base.Series} {
+		for _, s := range list {
+			key := seriesKey{quantile: s.Name, group: s.Group}
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	var rows []DeltaRow
+	for _, key := range keys {
+		baseData, candData := baseSeries[key], candSeries[key]
+		if candData != nil {
+			merged.Series = append(merged.Series, SeriesTemplate{Name: key.quantile, Group: key.group, Run: SeriesRunCandidate, Data: candData})
+		}
+		if baseData != nil {
+			merged.Series = append(merged.Series, SeriesTemplate{Name: key.quantile, Group: key.group, Run: SeriesRunBaseline, Data: baseData})
+		}
+
+		for i, x := range axis.Data {
+			row := DeltaRow{
+				Chart:       merged.Name,
+				Description: merged.Description,
+				Unit:        merged.YAxisName,
+				Quantile:    key.quantile,
+				Group:       key.group,
+				X:           x,
+			}
+			if baseData != nil {
+				row.Baseline = baseData[i]
+			}
+			if candData != nil {
+				row.Candidate = candData[i]
+			}
+			if row.Baseline == nil && row.Candidate == nil {
+				continue
+			}
+			if row.Baseline != nil && row.Candidate != nil {
+				delta := *row.Candidate - *row.Baseline
+				row.Delta = &delta
+				if *row.Baseline != 0 {
+					percent := delta / *row.Baseline * 100
+					row.Percent = &percent
+					row.Regression = percent > tolerance
+					row.Improvement = percent < -tolerance
+				} else {
+					// any growth from zero is beyond percent tolerance
+					row.Regression = delta > 0
+					row.Improvement = delta < 0
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	return merged, rows
+}
+
+// alignSeries returns series data by key aligned with axis.
+func alignSeries(series []SeriesTemplate, from, to XAxis) map[seriesKey][]*float64 {
+	index := make(map[string]int, len(from.Data))
+	for i, x := range from.Data {
+		index[x] = i
+	}
+
+	result := make(map[seriesKey][]*float64, len(series))
+	for _, s := range series {
+		data := make([]*float64, len(to.Data))
+		for i, x := range to.Data {
+			if j, ok := index[x]; ok && j < len(s.Data) {
+				data[i] = s.Data[j]
+			}
+		}
+		result[seriesKey{quantile: s.Name, group: s.Group}] = data
+	}
+	return result
+}
+
+// mergeXAxis returns axis with values of both axes, numeric values are sorted.
+func mergeXAxis(first, second XAxis) XAxis {
+	result := XAxis{Name: first.Name, Property: first.Property, Data: make([]string, 0, len(first.Data)+len(second.Data))}
+	if result.Name == "" {
+		result.Name = second.Name
+	}
+	if result.Property == "" {
+		result.Property = second.Property
+	}
+
+	seen := make(map[string]bool)
+	numeric := true
+	for _, list := range [][]string{first.Data, second.Data} {
+		for _, x := range list {
+			if seen[x] {
+				continue
+			}
+			seen[x] = true
+			result.Data = append(result.Data, x)
+			if _, err := strconv.ParseFloat(x, 64); err != nil {
+				numeric = false
+			}
+		}
+	}
+
+	if numeric {
+		sort.SliceStable(result.Data, func(i, j int) bool {
+			a, _ := strconv.ParseFloat(result.Data[i], 64)
+			b, _ := strconv.ParseFloat(result.Data[j], 64)
+			return a < b
+		})
+	}
+	return result
+}
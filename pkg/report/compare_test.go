@@ -0,0 +1,117 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestCompare(t *testing.T) {
+	baseline := &TemplateData{
+		GitBranch:     "master",
+		GitCommitHash: "1111111111",
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", YAxisName: "ms", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(100), float(200)}},
+			}},
+			{Name: "removed", Series: []SeriesTemplate{{Name: "0.5", Data: []*float64{float(1), nil}}}},
+		},
+		xAxis: XAxis{Name: "Nodes count", Data: []string{"5", "10"}},
+	}
+	candidate := &TemplateData{
+		GitBranch:     "feature",
+		GitCommitHash: "2222222222",
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", YAxisName: "ms", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(90), float(220), float(300)}},
+			}},
+		},
+		xAxis: XAxis{Name: "Nodes count", Data: []string{"5", "10", "15"}},
+	}
+
+	data := Compare(baseline, candidate, 5)
+	require.Equal(t, []string{"5", "10", "15"}, data.xAxis.Data)
+	require.Len(t, data.ChartConfig, 2)
+	require.Equal(t, "feature", data.GitBranch)
+	require.Equal(t, []string{"chart removed is missing in candidate"}, data.Warnings)
+
+	chart := data.ChartConfig[0]
+	require.Len(t, chart.Series, 2)
+	require.Equal(t, SeriesRunCandidate, chart.Series[0].Run)
+	require.Equal(t, []interface{}{90.0, 220.0, 300.0}, seriesValues(chart.Series[0]))
+	require.Equal(t, SeriesRunBaseline, chart.Series[1].Run)
+	require.Equal(t, []interface{}{100.0, 200.0, nil}, seriesValues(chart.Series[1]))
+
+	cmp := data.Comparison
+	require.NotNil(t, cmp)
+	require.Equal(t, "master@11111111", cmp.Baseline)
+	require.Equal(t, "feature@22222222", cmp.Candidate)
+	require.Equal(t, 1, cmp.Regressions())
+
+	rows := cmp.Rows[:3]
+	require.Equal(t, "-10.00", rows[0].DeltaText())
+	require.Equal(t, "-10.00%", rows[0].PercentText())
+	require.True(t, rows[0].Improvement)
+	require.Equal(t, "+10.00%", rows[1].PercentText())
+	require.True(t, rows[1].Regression)
+	require.Equal(t, "-", rows[2].BaselineText())
+	require.Nil(t, rows[2].Delta)
+	require.False(t, rows[2].Regression)
+}
+
+func TestCompare_ZeroValues(t *testing.T) {
+	chart := func(values ...*float64) *TemplateData {
+		return &TemplateData{
+			ChartConfig: []ChartTemplate{{Name: "errors", Series: []SeriesTemplate{{Name: "0.5", Data: values}}}},
+			xAxis:       XAxis{Data: []string{"5", "10", "15"}},
+		}
+	}
+
+	data := Compare(chart(float(0), float(0), float(100)), chart(float(3), float(0), float(101)), 0)
+	rows := data.Comparison.Rows
+	require.Len(t, rows, 3)
+	// growth from zero
+	require.True(t, rows[0].Regression)
+	require.Equal(t, "+inf%", rows[0].PercentText())
+	require.False(t, rows[1].Regression)
+	require.Equal(t, "-", rows[1].PercentText())
+	// zero tolerance
+	require.True(t, rows[2].Regression)
+	require.Equal(t, 2, data.Comparison.Regressions())
+}
+
+func TestWebdavClient_CompareReader(t *testing.T) {
+	fs := memFS{}
+	loadTestData(t, fs, "master")
+	loadTestData(t, fs, "run")
+	var index ConfigFileJSON
+	require.NoError(t, json.Unmarshal(fs["/master/config.json"], &index))
+	index.Run = &replicator.RunMetadata{Branch: "master", Hash: "ccddee"}
+	buf, err := json.Marshal(index)
+	require.NoError(t, err)
+	fs["/master/config.json"] = buf
+
+	client := newTestClient(fs, "run")
+	client.cfg.Compare.Baseline = "master"
+	client.cfg.Compare.Tolerance = 5
+	reader, ok := client.CompareReader()
+	require.True(t, ok)
+
+	data, err := reader.ReadTemplateData()
+	require.NoError(t, err)
+	require.Equal(t, "master@ccddee", data.Comparison.Baseline)
+	require.Equal(t, 0, data.Comparison.Regressions())
+	require.Len(t, data.ChartConfig[0].Series, 8)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, MakeReport(reader, out, Options{CDN: true}))
+	require.Contains(t, out.String(), "Comparison of master@aabbcc with baseline master@ccddee")
+}
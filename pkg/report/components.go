@@ -0,0 +1,91 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/insolar/consensus-reports/pkg/middleware"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+// ComponentTemplate is a repository of run with links to its branch and commit, links are empty without repo.
+type ComponentTemplate struct {
+	Name      string `json:"name"`
+	Branch    string `json:"branch"`
+	Commit    string `json:"commit"`
+	BranchURL string `json:"branch_url,omitempty"`
+	CommitURL string `json:"commit_url,omitempty"`
+}
+
+// ShortCommit returns first 8 symbols of commit.
+func (c ComponentTemplate) ShortCommit() string {
+	if len(c.Commit) > 8 {
+		return c.Commit[:8]
+	}
+	return c.Commit
+}
+
+func newComponentTemplate(c replicator.Component) ComponentTemplate {
+	ct := ComponentTemplate{Name: c.Name, Branch: c.Branch, Commit: c.Commit}
+	repo := strings.TrimRight(strings.Replace(c.Repo, "{name}", c.Name, -1), "/")
+	if repo == "" {
+		return ct
+	}
+	// github and gitlab links
+	if c.Branch != "" {
+		ct.BranchURL = repo + "/tree/" + c.Branch
+	}
+	if c.Commit != "" {
+		ct.CommitURL = repo + "/commit/" + c.Commit
+	}
+	return ct
+}
+
+// runComponents merges configured components with components of run metadata.
+// Configured components take missing repo, branch and commit from metadata by name,
+// the first configured component without them takes branch and hash of run.
+// Components of metadata which are not configured go after configured ones,
+// components without branch and commit are skipped.
+func runComponents(configured []middleware.ComponentConfig, run *replicator.RunMetadata, branch, hash string) []ComponentTemplate {
+	var fromRun []replicator.Component
+	if run != nil {
+		fromRun = run.Components
+	}
+	byName := make(map[string]replicator.Component, len(fromRun))
+	for _, c := range fromRun {
+		byName[c.Name] = c
+	}
+
+	components := make([]replicator.Component, 0, len(configured)+len(fromRun))
+	used := make(map[string]bool, len(configured))
+	runGit := branch != "" || hash != ""
+	for _, cfg := range configured {
+		c := replicator.Component{Name: cfg.Name, Repo: cfg.Repo, Branch: cfg.Branch, Commit: cfg.Commit}
+		if meta, ok := byName[c.Name]; ok {
+			if c.Repo == "" {
+				c.Repo = meta.Repo
+			}
+			if c.Branch == "" && c.Commit == "" {
+				c.Branch, c.Commit = meta.Branch, meta.Commit
+			}
+		}
+		if c.Branch == "" && c.Commit == "" && runGit {
+			c.Branch, c.Commit = branch, hash
+			runGit = false
+		}
+		used[c.Name] = true
+		components = append(components, c)
+	}
+	for _, c := range fromRun {
+		if !used[c.Name] {
+			components = append(components, c)
+		}
+	}
+
+	result := make([]ComponentTemplate, 0, len(components))
+	for _, c := range components {
+		if c.Branch != "" || c.Commit != "" {
+			result = append(result, newComponentTemplate(c))
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/middleware"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+func TestRunComponents(t *testing.T) {
+	configured := []middleware.ComponentConfig{
+		{Name: "assured-ledger", Repo: "https://github.com/insolar/{name}/"},
+		{Name: "network", Repo: "https://gitlab.com/insolar/{name}", Branch: "feature"},
+		{Name: "unknown", Repo: "https://github.com/insolar/{name}"},
+	}
+	run := &replicator.RunMetadata{
+		Branch: "master",
+		Hash:   "aabbcc",
+		Components: []replicator.Component{
+			{Name: "network", Branch: "master", Commit: "ddeeff"},
+			{Name: "consensus", Commit: "112233"},
+		},
+	}
+
+	components := runComponents(configured, run, run.Branch, run.Hash)
+	require.Equal(t, []ComponentTemplate{
+		{
+			Name: "assured-ledger", Branch: "master", Commit: "aabbcc",
+			BranchURL: "https://github.com/insolar/assured-ledger/tree/master",
+			CommitURL: "https://github.com/insolar/assured-ledger/commit/aabbcc",
+		},
+		{
+			Name: "network", Branch: "feature",
+			BranchURL: "https://gitlab.com/insolar/network/tree/feature",
+		},
+		{Name: "consensus", Commit: "112233"},
+	}, components)
+
+	require.Empty(t, runComponents(nil, nil, "", ""))
+}
+
+func TestMakeReport_Components(t *testing.T) {
+	data := &TemplateData{
+		GitBranch:     "master",
+		GitCommitHash: "aabbccddeeff",
+		Components: []ComponentTemplate{
+			newComponentTemplate(replicator.Component{Name: "assured-ledger", Repo: "https://github.com/insolar/{name}", Branch: "master", Commit: "aabbccddeeff"}),
+			{Name: "consensus", Commit: "112233"},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, MakeReport(staticReader{data}, buf, Options{CDN: true}))
+	out := buf.String()
+	require.Contains(t, out, `<a target="_blank" href="https://github.com/insolar/assured-ledger/tree/master">master</a>`)
+	require.Contains(t, out, `<a target="_blank" href="https://github.com/insolar/assured-ledger/commit/aabbccddeeff" title="aabbccddeeff">aabbccdd</a>`)
+	require.Contains(t, out, `<span title="112233">112233</span>`)
+
+	buf.Reset()
+	require.NoError(t, MakeReport(staticReader{&TemplateData{}}, buf, Options{CDN: true}))
+	require.NotContains(t, buf.String(), `class="components"`)
+}
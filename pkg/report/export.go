@@ -0,0 +1,168 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Report formats.
+const (
+	FormatHTML = "html"
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+const ExportErrorMessage = "Failed to export report data"
+
+// ReportFileName returns name of report file of format in report directory.
+func ReportFileName(format string) string {
+	if format == FormatHTML {
+		return DefaultReportFileName
+	}
+	return "report." + format
+}
+
+// ParseFormats parses comma separated list of report formats.
+func ParseFormats(list string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(list, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		switch f {
+		case "":
+			continue
+		case FormatHTML, FormatCSV, FormatJSON:
+		default:
+			return nil, errors.Errorf("unknown report format %q, use html, csv or json", f)
+		}
+		if !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	if len(formats) == 0 {
+		return nil, errors.New("report format is required")
+	}
+	return formats, nil
+}
+
+// Render writes report in format.
+func Render(format string, reader TemplateDataReader, wr io.Writer, opts Options) error {
+	switch format {
+	case FormatHTML:
+		return MakeReport(reader, wr, opts)
+	case FormatCSV:
+		return MakeCSV(reader, wr)
+	case FormatJSON:
+		return MakeJSON(reader, wr)
+	}
+	return errors.Errorf("unknown report format %q", format)
+}
+
+// DataPoint is one chart value in long format.
+type DataPoint struct {
+	Chart    string  `json:"chart"`
+	Unit     string  `json:"unit"`
+	Quantile string  `json:"quantile"`
+	Series   string  `json:"series"`
+	X        string  `json:"x"`
+	Value    float64 `json:"value"`
+	Run      string  `json:"run"`
+}
+
+var csvHeader = []string{"chart", "unit", "quantile", "series", "x", "value", "run"}
+
+// DataPoints returns chart values of template data, missing values are skipped.
+// Run is a name of run, it is baseline or candidate run name for comparison report.
+func DataPoints(c *TemplateData) []DataPoint {
+	runName := func(s SeriesTemplate) string {
+		if c.Comparison != nil {
+			switch s.Run {
+			case SeriesRunBaseline:
+				return c.Comparison.Baseline
+			case SeriesRunCandidate:
+				return c.Comparison.Candidate
+			}
+		}
+		return c.RunName()
+	}
+
+	points := make([]DataPoint, 0)
+	for _, ct := range c.ChartConfig {
+		for _, s := range ct.Series {
+			for i, v := range s.Data {
+				if v == nil || i >= len(c.xAxis.Data) {
+					continue
+				}
+				points = append(points, DataPoint{
+					Chart:    chartName(ct),
+					Unit:     ct.YAxisName,
+					Quantile: s.Name,
+					Series:   s.Group,
+					X:        c.xAxis.Data[i],
+					Value:    *v,
+					Run:      runName(s),
+				})
+			}
+		}
+	}
+	return points
+}
+
+// MakeCSV writes chart values as csv with header.
+func MakeCSV(reader TemplateDataReader, wr io.Writer) error {
+	c, err := reader.ReadTemplateData()
+	if err != nil {
+		return errors.Wrap(err, ExportErrorMessage)
+	}
+
+	w := csv.NewWriter(wr)
+	if err := w.Write(csvHeader); err != nil {
+		return errors.Wrap(err, ExportErrorMessage)
+	}
+	for _, p := range DataPoints(c) {
+		record := []string{p.Chart, p.Unit, p.Quantile, p.Series, p.X, strconv.FormatFloat(p.Value, 'f', -1, 64), p.Run}
+		if err := w.Write(record); err != nil {
+			return errors.Wrap(err, ExportErrorMessage)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return errors.Wrap(err, ExportErrorMessage)
+	}
+	return nil
+}
+
+// exportJSON is a json export of report with x-axis and chart values.
+type exportJSON struct {
+	Branch string      `json:"branch"`
+	Hash   string      `json:"hash"`
+	XAxis  XAxis       `json:"xaxis"`
+	Data   []DataPoint `json:"data"`
+}
+
+// MakeJSON writes x-axis and chart values as json.
+func MakeJSON(reader TemplateDataReader, wr io.Writer) error {
+	c, err := reader.ReadTemplateData()
+	if err != nil {
+		return errors.Wrap(err, ExportErrorMessage)
+	}
+
+	enc := json.NewEncoder(wr)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(exportJSON{
+		Branch: c.GitBranch,
+		Hash:   c.GitCommitHash,
+		XAxis:  c.xAxis,
+		Data:   DataPoints(c),
+	})
+	if err != nil {
+		return errors.Wrap(err, ExportErrorMessage)
+	}
+	return nil
+}
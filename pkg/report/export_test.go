@@ -0,0 +1,80 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormats(t *testing.T) {
+	formats, err := ParseFormats("html, CSV,json,csv")
+	require.NoError(t, err)
+	require.Equal(t, []string{FormatHTML, FormatCSV, FormatJSON}, formats)
+
+	_, err = ParseFormats("xml")
+	require.Error(t, err)
+	_, err = ParseFormats(" ")
+	require.Error(t, err)
+
+	require.Equal(t, "index.html", ReportFileName(FormatHTML))
+	require.Equal(t, "report.csv", ReportFileName(FormatCSV))
+}
+
+func exportTestData() *TemplateData {
+	return &TemplateData{
+		GitBranch:     "master",
+		GitCommitHash: "aabbcc",
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration_50ms", Metric: "phase2_duration", YAxisName: "ms", Series: []SeriesTemplate{
+				{Name: "0.5", Group: "latency 50ms", Data: []*float64{float(1.5), nil}},
+			}},
+		},
+		xAxis: XAxis{Name: "Nodes count", Data: []string{"5", "10"}},
+	}
+}
+
+func TestMakeCSV(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, MakeCSV(staticReader{exportTestData()}, out))
+	require.Equal(t, "chart,unit,quantile,series,x,value,run\n"+
+		"phase2_duration,ms,0.5,latency 50ms,5,1.5,master@aabbcc\n", out.String())
+}
+
+func TestMakeJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, MakeJSON(staticReader{exportTestData()}, out))
+
+	var result exportJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	require.Equal(t, "master", result.Branch)
+	require.Equal(t, []string{"5", "10"}, result.XAxis.Data)
+	require.Equal(t, []DataPoint{{
+		Chart: "phase2_duration", Unit: "ms", Quantile: "0.5", Series: "latency 50ms", X: "5", Value: 1.5, Run: "master@aabbcc",
+	}}, result.Data)
+}
+
+func TestDataPoints_Comparison(t *testing.T) {
+	baseline := exportTestData()
+	baseline.GitCommitHash = "ccddee"
+	data := Compare(baseline, exportTestData(), 5)
+
+	points := DataPoints(data)
+	require.Len(t, points, 2)
+	require.Equal(t, "master@aabbcc", points[0].Run)
+	require.Equal(t, "master@ccddee", points[1].Run)
+}
+
+func TestCachedReader(t *testing.T) {
+	fs := memFS{}
+	loadTestData(t, fs, "run")
+	reader := &CachedReader{Reader: newTestClient(fs, "run")}
+
+	first, err := reader.ReadTemplateData()
+	require.NoError(t, err)
+	delete(fs, "/run/config.json")
+	second, err := reader.ReadTemplateData()
+	require.NoError(t, err)
+	require.True(t, first == second)
+}
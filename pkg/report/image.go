@@ -0,0 +1,411 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Chart image formats.
+const (
+	ImageFormatSVG = "svg"
+	ImageFormatPNG = "png"
+)
+
+const RenderChartErrorMessage = "Failed to render chart"
+
+const (
+	imageWidth  = 840
+	imageHeight = 420
+
+	plotLeft   = 70
+	plotRight  = imageWidth - 20
+	plotTop    = 60
+	plotBottom = imageHeight - 110
+
+	// charWidth is a width of basic font glyph, svg text is measured with it too.
+	charWidth  = 7
+	lineHeight = 16
+)
+
+// palette is the default echarts palette, so images look like html charts.
+var palette = []color.RGBA{
+	{0xc2, 0x35, 0x31, 0xff}, {0x2f, 0x45, 0x54, 0xff}, {0x61, 0xa0, 0xa8, 0xff}, {0xd4, 0x82, 0x65, 0xff},
+	{0x91, 0xc7, 0xae, 0xff}, {0x74, 0x9f, 0x83, 0xff}, {0xca, 0x86, 0x22, 0xff}, {0xbd, 0xa2, 0x9a, 0xff},
+	{0x6e, 0x70, 0x74, 0xff}, {0x54, 0x65, 0x70, 0xff}, {0xc4, 0xcc, 0xd3, 0xff},
+}
+
+var (
+	textColor  = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	axisColor  = color.RGBA{0x6e, 0x70, 0x79, 0xff}
+	gridColor  = color.RGBA{0xe0, 0xe6, 0xf1, 0xff}
+	limitColor = color.RGBA{0xc2, 0x35, 0x31, 0xff}
+)
+
+type lineStyle int
+
+const (
+	lineSolid lineStyle = iota
+	lineDashed
+	lineDotted
+)
+
+type textAnchor int
+
+const (
+	anchorStart textAnchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+// canvas is a drawing surface of chart image, y grows down.
+type canvas interface {
+	line(x1, y1, x2, y2 float64, c color.RGBA, width float64, style lineStyle)
+	rect(x, y, w, h float64, c color.RGBA)
+	// text draws single line with baseline at y.
+	text(x, y float64, s string, anchor textAnchor, c color.RGBA)
+}
+
+// ParseImageFormats parses comma separated list of chart image formats.
+func ParseImageFormats(list string) ([]string, error) {
+	var formats []string
+	for _, f := range strings.Split(list, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		switch f {
+		case "":
+			continue
+		case ImageFormatSVG, ImageFormatPNG:
+			formats = append(formats, f)
+		default:
+			return nil, errors.Errorf("unknown image format %q, use svg or png", f)
+		}
+	}
+	return formats, nil
+}
+
+// ChartImageFilename returns name of chart image file in report directory.
+func ChartImageFilename(chart, format string) string {
+	return "chart-" + chart + "." + format
+}
+
+// ChartImage is a rendered chart.
+type ChartImage struct {
+	Chart    string
+	Filename string
+	Data     []byte
+}
+
+// MakeChartImages renders every chart of report in every format.
+func MakeChartImages(reader TemplateDataReader, formats []string) ([]ChartImage, error) {
+	c, err := reader.ReadTemplateData()
+	if err != nil {
+		return nil, errors.Wrap(err, RenderChartErrorMessage)
+	}
+
+	images := make([]ChartImage, 0, len(c.ChartConfig)*len(formats))
+	for _, ct := range c.ChartConfig {
+		for _, format := range formats {
+			data, err := RenderChart(ct, c.xAxis, format)
+			if err != nil {
+				return nil, err
+			}
+			images = append(images, ChartImage{Chart: ct.Name, Filename: ChartImageFilename(ct.Name, format), Data: data})
+		}
+	}
+	return images, nil
+}
+
+// RenderChart renders chart with axes, legend and units as svg or png image.
+func RenderChart(chart ChartTemplate, xAxis XAxis, format string) ([]byte, error) {
+	switch format {
+	case ImageFormatSVG:
+		cv := newSVGCanvas()
+		drawChart(cv, chart, xAxis)
+		return cv.bytes(), nil
+	case ImageFormatPNG:
+		cv := newPNGCanvas()
+		drawChart(cv, chart, xAxis)
+		buf := &bytes.Buffer{}
+		if err := png.Encode(buf, cv.img); err != nil {
+			return nil, errors.Wrap(err, RenderChartErrorMessage)
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, errors.Errorf("unknown image format %q", format)
+}
+
+// seriesLabel returns legend name of series like seriesName of html template.
+func seriesLabel(s SeriesTemplate) string {
+	parts := make([]string, 0, 3)
+	if s.Name != "" {
+		parts = append(parts, s.Name+" quantile")
+	}
+	if s.Group != "" {
+		parts = append(parts, s.Group)
+	}
+	if s.Run != "" {
+		parts = append(parts, s.Run)
+	}
+	return strings.Join(parts, ", ")
+}
+
+type plotLine struct {
+	label  string
+	values []*float64
+	color  color.RGBA
+	width  float64
+	style  lineStyle
+	marks  bool
+}
+
+func chartLines(chart ChartTemplate) []plotLine {
+	lines := make([]plotLine, 0, len(chart.Series)+len(chart.Limits))
+	for i, s := range chart.Series {
+		style := lineSolid
+		if s.Run == SeriesRunBaseline {
+			style = lineDashed
+		}
+		lines = append(lines, plotLine{
+			label: seriesLabel(s), values: s.Data, color: palette[i%len(palette)], width: 2, style: style, marks: true,
+		})
+	}
+	for _, l := range chart.Limits {
+		lines = append(lines, plotLine{label: l.Name, values: l.Values, color: limitColor, width: 2, style: lineDotted})
+	}
+	return lines
+}
+
+// valueRange returns y-axis range with nice ticks for values of lines.
+func valueRange(lines []plotLine) (min, max, step float64) {
+	min, max = 0, 0
+	for _, l := range lines {
+		for _, v := range l.values {
+			if v == nil {
+				continue
+			}
+			min = math.Min(min, *v)
+			max = math.Max(max, *v)
+		}
+	}
+	if max == min {
+		max = min + 1
+	}
+
+	step = niceNumber((max - min) / 5)
+	return math.Floor(min/step) * step, math.Ceil(max/step) * step, step
+}
+
+// niceNumber rounds value up to 1, 2, 2.5 or 5 multiplied by power of 10.
+func niceNumber(v float64) float64 {
+	exp := math.Floor(math.Log10(v))
+	base := math.Pow(10, exp)
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if v <= m*base {
+			return m * base
+		}
+	}
+	return 10 * base
+}
+
+func formatTick(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.3f", v), "0"), ".")
+}
+
+func drawChart(cv canvas, chart ChartTemplate, xAxis XAxis) {
+	title := chart.Description
+	if title == "" {
+		title = chart.Name
+	}
+	cv.text(imageWidth/2, 24, title, anchorMiddle, textColor)
+	if chart.YAxisName != "" {
+		cv.text(plotLeft, plotTop-14, chart.YAxisName, anchorMiddle, axisColor)
+	}
+
+	lines := chartLines(chart)
+	min, max, step := valueRange(lines)
+	y := func(v float64) float64 {
+		return plotBottom - (v-min)/(max-min)*(plotBottom-plotTop)
+	}
+	for v := min; v <= max+step/2; v += step {
+		cv.line(plotLeft, y(v), plotRight, y(v), gridColor, 1, lineSolid)
+		cv.text(plotLeft-6, y(v)+4, formatTick(v), anchorEnd, axisColor)
+	}
+
+	n := len(xAxis.Data)
+	band := float64(plotRight-plotLeft) / math.Max(float64(n), 1)
+	x := func(i int) float64 {
+		return plotLeft + (float64(i)+0.5)*band
+	}
+	cv.line(plotLeft, plotBottom, plotRight, plotBottom, axisColor, 1, lineSolid)
+	for i, value := range xAxis.Data {
+		cv.line(x(i), plotBottom, x(i), plotBottom+4, axisColor, 1, lineSolid)
+		cv.text(x(i), plotBottom+18, value, anchorMiddle, axisColor)
+	}
+	cv.text((plotLeft+plotRight)/2, plotBottom+38, xAxis.Name, anchorMiddle, axisColor)
+
+	for _, l := range lines {
+		for i := 0; i < n && i < len(l.values); i++ {
+			if l.values[i] == nil {
+				continue
+			}
+			if i+1 < n && i+1 < len(l.values) && l.values[i+1] != nil {
+				cv.line(x(i), y(*l.values[i]), x(i+1), y(*l.values[i+1]), l.color, l.width, l.style)
+			}
+			if l.marks {
+				cv.rect(x(i)-2, y(*l.values[i])-2, 4, 4, l.color)
+			}
+		}
+	}
+
+	drawLegend(cv, lines, plotBottom+62)
+}
+
+// drawLegend draws legend items in centered rows starting at top.
+func drawLegend(cv canvas, lines []plotLine, top float64) {
+	const swatch, gap = 20, 16
+
+	var rows [][]plotLine
+	var widths []float64
+	width := 0.0
+	for _, l := range lines {
+		w := float64(swatch + 4 + charWidth*len(l.label))
+		if len(rows) == 0 || width+gap+w > imageWidth-40 {
+			rows = append(rows, nil)
+			widths = append(widths, 0)
+			width = -gap
+		}
+		width += gap + w
+		rows[len(rows)-1] = append(rows[len(rows)-1], l)
+		widths[len(widths)-1] = width
+	}
+
+	for r, row := range rows {
+		x := (imageWidth - widths[r]) / 2
+		y := top + float64(r*lineHeight)
+		for _, l := range row {
+			cv.line(x, y-4, x+swatch, y-4, l.color, l.width, l.style)
+			cv.text(x+swatch+4, y, l.label, anchorStart, textColor)
+			x += float64(swatch+4+charWidth*len(l.label)) + gap
+		}
+	}
+}
+
+type svgCanvas struct {
+	buf *bytes.Buffer
+}
+
+func newSVGCanvas() *svgCanvas {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="monospace" font-size="12">`+"\n",
+		imageWidth, imageHeight, imageWidth, imageHeight)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", imageWidth, imageHeight)
+	return &svgCanvas{buf: buf}
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (cv *svgCanvas) line(x1, y1, x2, y2 float64, c color.RGBA, width float64, style lineStyle) {
+	dash := ""
+	switch style {
+	case lineDashed:
+		dash = ` stroke-dasharray="6,4"`
+	case lineDotted:
+		dash = ` stroke-dasharray="2,3"`
+	}
+	fmt.Fprintf(cv.buf, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%g"%s/>`+"\n",
+		x1, y1, x2, y2, svgColor(c), width, dash)
+}
+
+func (cv *svgCanvas) rect(x, y, w, h float64, c color.RGBA) {
+	fmt.Fprintf(cv.buf, `<rect x="%.1f" y="%.1f" width="%g" height="%g" fill="%s"/>`+"\n", x, y, w, h, svgColor(c))
+}
+
+func (cv *svgCanvas) text(x, y float64, s string, anchor textAnchor, c color.RGBA) {
+	anchors := map[textAnchor]string{anchorStart: "start", anchorMiddle: "middle", anchorEnd: "end"}
+	fmt.Fprintf(cv.buf, `<text x="%.1f" y="%.1f" text-anchor="%s" fill="%s">`, x, y, anchors[anchor], svgColor(c))
+	_ = xml.EscapeText(cv.buf, []byte(s))
+	cv.buf.WriteString("</text>\n")
+}
+
+func (cv *svgCanvas) bytes() []byte {
+	cv.buf.WriteString("</svg>\n")
+	return cv.buf.Bytes()
+}
+
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func newPNGCanvas() *pngCanvas {
+	img := image.NewRGBA(image.Rect(0, 0, imageWidth, imageHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	return &pngCanvas{img: img}
+}
+
+func (cv *pngCanvas) line(x1, y1, x2, y2 float64, c color.RGBA, width float64, style lineStyle) {
+	length := math.Hypot(x2-x1, y2-y1)
+	steps := int(math.Ceil(length))
+	if steps == 0 {
+		steps = 1
+	}
+
+	dash, space := 0, 0
+	switch style {
+	case lineDashed:
+		dash, space = 6, 4
+	case lineDotted:
+		dash, space = 2, 3
+	}
+
+	half := int(width / 2)
+	for i := 0; i <= steps; i++ {
+		if dash > 0 && i%(dash+space) >= dash {
+			continue
+		}
+		t := float64(i) / float64(steps)
+		px := int(math.Round(x1 + (x2-x1)*t))
+		py := int(math.Round(y1 + (y2-y1)*t))
+		for dx := -half; dx <= half; dx++ {
+			for dy := -half; dy <= half; dy++ {
+				if (dx != 0 || dy != 0) && width < 2 {
+					continue
+				}
+				cv.img.SetRGBA(px+dx, py+dy, c)
+			}
+		}
+	}
+}
+
+func (cv *pngCanvas) rect(x, y, w, h float64, c color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(cv.img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func (cv *pngCanvas) text(x, y float64, s string, anchor textAnchor, c color.RGBA) {
+	d := &font.Drawer{Dst: cv.img, Src: image.NewUniform(c), Face: basicfont.Face7x13}
+	width := d.MeasureString(s).Round()
+	switch anchor {
+	case anchorMiddle:
+		x -= float64(width) / 2
+	case anchorEnd:
+		x -= float64(width)
+	}
+	d.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	d.DrawString(s)
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func imageTestChart() (ChartTemplate, XAxis) {
+	chart := ChartTemplate{
+		Name:        "phase2_duration",
+		Description: "Duration of consensus phase2",
+		YAxisName:   "ms",
+		Series: []SeriesTemplate{
+			{Name: "0.5", Data: []*float64{float(10), nil, float(30)}},
+			{Name: "0.5", Run: SeriesRunBaseline, Data: []*float64{float(12), float(20), float(25)}},
+		},
+		Limits: []LimitTemplate{{Name: "limit phase2_duration", Values: []*float64{float(40), float(40), float(40)}}},
+	}
+	return chart, XAxis{Name: "Nodes count", Data: []string{"5", "10", "15"}}
+}
+
+func TestRenderChart_SVG(t *testing.T) {
+	chart, xAxis := imageTestChart()
+	data, err := RenderChart(chart, xAxis, ImageFormatSVG)
+	require.NoError(t, err)
+
+	require.NoError(t, xml.Unmarshal(data, new(interface{})))
+	svg := string(data)
+	for _, text := range []string{"Duration of consensus phase2", ">ms<", ">Nodes count<", ">15<",
+		">0.5 quantile<", ">0.5 quantile, baseline<", ">limit phase2_duration<"} {
+		require.Contains(t, svg, text)
+	}
+	require.Contains(t, svg, `stroke-dasharray="6,4"`)
+}
+
+func TestRenderChart_PNG(t *testing.T) {
+	chart, xAxis := imageTestChart()
+	data, err := RenderChart(chart, xAxis, ImageFormatPNG)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, imageWidth, img.Bounds().Dx())
+	require.Equal(t, imageHeight, img.Bounds().Dy())
+
+	_, err = RenderChart(chart, xAxis, "gif")
+	require.Error(t, err)
+}
+
+func TestMakeChartImages(t *testing.T) {
+	fs := memFS{}
+	loadTestData(t, fs, "run")
+
+	formats, err := ParseImageFormats("svg, png")
+	require.NoError(t, err)
+	images, err := MakeChartImages(newTestClient(fs, "run"), formats)
+	require.NoError(t, err)
+	require.Len(t, images, 18)
+	require.Equal(t, "chart-sent_traffic_per_node.svg", images[0].Filename)
+	require.Equal(t, "chart-sent_traffic_per_node.png", images[1].Filename)
+
+	_, err = ParseImageFormats("jpeg")
+	require.Error(t, err)
+}
+
+func TestValueRange(t *testing.T) {
+	min, max, step := valueRange([]plotLine{{values: []*float64{float(3), float(987)}}})
+	require.Equal(t, 0.0, min)
+	require.Equal(t, 1000.0, max)
+	require.Equal(t, 200.0, step)
+
+	min, max, step = valueRange(nil)
+	require.Equal(t, 0.0, min)
+	require.Equal(t, 1.0, max)
+	require.Equal(t, 0.2, step)
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const MakeJUnitErrorMessage = "Failed to make junit report"
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Name    string           `xml:"name,attr"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// MakeJUnit writes threshold checks as junit xml, every chart is a test suite
+// and every checked value is a test case. Rules which can't be checked are errors.
+func MakeJUnit(reader TemplateDataReader, wr io.Writer) error {
+	c, err := reader.ReadTemplateData()
+	if err != nil {
+		return errors.Wrap(err, MakeJUnitErrorMessage)
+	}
+	if c.Verdict == nil {
+		return errors.New("junit report requires threshold rules")
+	}
+
+	result := junitTestSuites{Name: "consensus thresholds " + c.RunName()}
+	suiteIndex := make(map[string]int)
+	suite := func(name string) *junitTestSuite {
+		i, ok := suiteIndex[name]
+		if !ok {
+			i = len(result.Suites)
+			suiteIndex[name] = i
+			result.Suites = append(result.Suites, junitTestSuite{Name: name})
+		}
+		return &result.Suites[i]
+	}
+
+	for _, check := range c.Verdict.Checks {
+		s := suite(check.Chart)
+		tc := junitTestCase{
+			Name:      junitCaseName(check, c.xAxis.Name),
+			ClassName: "consensus." + check.Chart,
+		}
+		if !check.Passed {
+			unit := ""
+			if check.Unit != "" {
+				unit = " " + check.Unit
+			}
+			tc.Failure = &junitMessage{
+				Message: fmt.Sprintf("value %s%s exceeds limit %s%s", check.ValueText(), unit, check.LimitText(), unit),
+				Type:    "threshold",
+				Text: fmt.Sprintf("chart: %s\nquantile: %s\nx: %s\nvalue: %s\nlimit: %s\nunit: %s\n",
+					check.Chart, check.Quantile, check.X, check.ValueText(), check.LimitText(), check.Unit),
+			}
+			s.Failures++
+		}
+		s.Tests++
+		s.Cases = append(s.Cases, tc)
+	}
+
+	for _, msg := range c.Verdict.Errors {
+		s := suite("rules")
+		s.Tests++
+		s.Errors++
+		s.Cases = append(s.Cases, junitTestCase{
+			Name:      msg,
+			ClassName: "consensus.rules",
+			Error:     &junitMessage{Message: msg, Type: "rule"},
+		})
+	}
+
+	if _, err := io.WriteString(wr, xml.Header); err != nil {
+		return errors.Wrap(err, MakeJUnitErrorMessage)
+	}
+	enc := xml.NewEncoder(wr)
+	enc.Indent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return errors.Wrap(err, MakeJUnitErrorMessage)
+	}
+	if _, err := io.WriteString(wr, "\n"); err != nil {
+		return errors.Wrap(err, MakeJUnitErrorMessage)
+	}
+	return nil
+}
+
+func junitCaseName(check RuleCheck, xName string) string {
+	parts := make([]string, 0, 3)
+	if check.Quantile != "" {
+		parts = append(parts, "quantile "+check.Quantile)
+	}
+	if check.Group != "" {
+		parts = append(parts, check.Group)
+	}
+	if xName == "" {
+		xName = "x"
+	}
+	parts = append(parts, strings.ToLower(xName)+" "+check.X)
+	return strings.Join(parts, ", ")
+}
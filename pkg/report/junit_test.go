@@ -0,0 +1,51 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeJUnit(t *testing.T) {
+	data := &TemplateData{
+		GitBranch: "master",
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", YAxisName: "ms", Series: []SeriesTemplate{
+				{Name: "0.99", Data: []*float64{float(10), float(30)}},
+			}},
+		},
+		xAxis: XAxis{Name: "Nodes count", Data: []string{"5", "10"}},
+	}
+	ApplyRules(data, Rules{Rules: []Rule{
+		{Chart: "phase2_duration", Quantile: "0.99", Max: 20},
+		{Chart: "unknown", Max: 1},
+	}})
+
+	out := &bytes.Buffer{}
+	require.NoError(t, MakeJUnit(staticReader{data}, out))
+
+	var result junitTestSuites
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &result))
+	require.Equal(t, "consensus thresholds master", result.Name)
+	require.Len(t, result.Suites, 2)
+
+	suite := result.Suites[0]
+	require.Equal(t, "phase2_duration", suite.Name)
+	require.Equal(t, 2, suite.Tests)
+	require.Equal(t, 1, suite.Failures)
+	require.Equal(t, "quantile 0.99, nodes count 5", suite.Cases[0].Name)
+	require.Nil(t, suite.Cases[0].Failure)
+	require.NotNil(t, suite.Cases[1].Failure)
+	require.Equal(t, "value 30.00 ms exceeds limit 20.00 ms", suite.Cases[1].Failure.Message)
+
+	require.Equal(t, "rules", result.Suites[1].Name)
+	require.Equal(t, 1, result.Suites[1].Errors)
+	require.NotNil(t, result.Suites[1].Cases[0].Error)
+}
+
+func TestMakeJUnit_WithoutRules(t *testing.T) {
+	err := MakeJUnit(staticReader{&TemplateData{}}, &bytes.Buffer{})
+	require.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package report
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+	"github.com/insolar/consensus-reports/pkg/middleware"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+const DefaultLiveDescription = "Live report"
+
+// LiveConfig sets ranges of live report which is made from prometheus without replication.
+type LiveConfig struct {
+	// Prometheus is a host of prometheus, live report is served if it is set.
+	Prometheus string `mapstructure:"prometheus"`
+	// Quantiles are default quantiles of charts.
+	Quantiles []string `mapstructure:"quantiles"`
+	// Groups are default ranges in format of metric replicator config.
+	Groups []middleware.GroupConfig `mapstructure:"groups"`
+}
+
+// ResultGrabber queries records of range from prometheus.
+type ResultGrabber interface {
+	GrabResult(ctx context.Context, quantiles []string, period replicator.PeriodInfo) (metricreplicator.ResultData, error)
+	Charts() []string
+}
+
+// LiveSource makes reports of ranges grabbed from prometheus in memory.
+type LiveSource struct {
+	cfg     Config
+	grabber ResultGrabber
+}
+
+// NewLiveSource creates source of live reports, x-axis and series are set by cfg like for replicated runs.
+func NewLiveSource(cfg Config, grabber ResultGrabber) LiveSource {
+	return LiveSource{cfg: cfg, grabber: grabber}
+}
+
+// Grabber returns grabber of source.
+func (s LiveSource) Grabber() ResultGrabber {
+	return s.grabber
+}
+
+// WithGrabber returns source with another grabber, e.g. caching one.
+func (s LiveSource) WithGrabber(grabber ResultGrabber) LiveSource {
+	s.grabber = grabber
+	return s
+}
+
+// Quantiles returns configured quantiles.
+func (s LiveSource) Quantiles() []string {
+	return s.cfg.Live.Quantiles
+}
+
+// Periods returns configured ranges.
+func (s LiveSource) Periods() []replicator.PeriodInfo {
+	return middleware.GroupsToReplicatorPeriods(s.cfg.Live.Groups)
+}
+
+// Reader returns reader of report of periods, prometheus queries are cancelled with ctx.
+func (s LiveSource) Reader(ctx context.Context, quantiles []string, periods []replicator.PeriodInfo) TemplateDataReader {
+	return liveReader{ctx: ctx, source: s, quantiles: quantiles, periods: periods}
+}
+
+type liveReader struct {
+	ctx       context.Context
+	source    LiveSource
+	quantiles []string
+	periods   []replicator.PeriodInfo
+}
+
+func (r liveReader) ReadTemplateData() (*TemplateData, error) {
+	if len(r.periods) == 0 {
+		return nil, errors.New("no ranges for live report")
+	}
+	if len(r.quantiles) == 0 {
+		return nil, errors.New("no quantiles for live report")
+	}
+
+	client := &WebdavClient{cfg: r.source.cfg}
+	reportCfg := &ConfigFileJSON{ChartNames: r.source.grabber.Charts(), Quantiles: r.quantiles}
+	files := make([]dataFile, 0, len(r.periods))
+	var skipped []string
+	for _, period := range r.periods {
+		result, err := r.source.grabber.GrabResult(r.ctx, r.quantiles, period)
+		if err != nil {
+			return nil, errors.Wrap(err, ReadTemplateDataErrorMessage)
+		}
+
+		filename := metricreplicator.PeriodFilename(period)
+		reportCfg.Files = append(reportCfg.Files, filename)
+		file, err := client.parseDataFile(filename, MetricFileJSON(result))
+		if err != nil {
+			skipped = append(skipped, "range "+filename+" is skipped: "+err.Error())
+			continue
+		}
+		files = append(files, file)
+	}
+
+	data, err := client.collectTemplateData(files, reportCfg)
+	if err != nil {
+		return nil, err
+	}
+	data.Directory = r.source.cfg.Live.Prometheus
+	data.Warnings = append(skipped, data.Warnings...)
+	return data, nil
+}
+
+// ParseLiveQuantile checks that quantile is a number in [0, 1] and returns it in canonical form,
+// because it is formatted into prometheus queries.
+func ParseLiveQuantile(value string) (string, error) {
+	q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return "", errors.Wrapf(err, "quantile %q: failed to parse", value)
+	}
+	if !(q >= 0 && q <= 1) {
+		return "", errors.Errorf("quantile %q: must be in [0, 1]", value)
+	}
+	return strconv.FormatFloat(q, 'f', -1, 64), nil
+}
+
+// ParseLiveRange parses range of live report in format <start>,<interval>[,<name>=<value>...],
+// start is unix time in seconds and properties are set like props of range in replicator config,
+// e.g. "1589292280,3m,network_size=17".
+func ParseLiveRange(value, description string) (replicator.PeriodInfo, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) < 2 {
+		return replicator.PeriodInfo{}, errors.Errorf("range %q: start and interval are required", value)
+	}
+
+	start, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil {
+		return replicator.PeriodInfo{}, errors.Wrapf(err, "range %q: failed to parse start", value)
+	}
+	interval, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return replicator.PeriodInfo{}, errors.Wrapf(err, "range %q: failed to parse interval", value)
+	}
+	if interval <= 0 {
+		return replicator.PeriodInfo{}, errors.Errorf("range %q: interval must be positive", value)
+	}
+
+	props := make([]replicator.PeriodProperty, 0, len(parts)-2)
+	for _, p := range parts[2:] {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return replicator.PeriodInfo{}, errors.Errorf("range %q: property %q is not in name=value format", value, p)
+		}
+		props = append(props, replicator.PeriodProperty{Name: strings.TrimSpace(kv[0]), Value: strings.TrimSpace(kv[1])})
+	}
+
+	if description == "" {
+		description = DefaultLiveDescription
+	}
+	return replicator.PeriodInfo{
+		Start:       time.Unix(start, 0),
+		End:         time.Unix(start, 0).Add(interval),
+		Interval:    interval,
+		Properties:  props,
+		Description: description,
+	}, nil
+}
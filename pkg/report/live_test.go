@@ -0,0 +1,112 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+	"github.com/insolar/consensus-reports/pkg/middleware"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+// fakeGrabber returns phase2 duration equal to network size multiplied by 10.
+type fakeGrabber struct {
+	err error
+}
+
+func (g fakeGrabber) GrabResult(ctx context.Context, quantiles []string, period replicator.PeriodInfo) (metricreplicator.ResultData, error) {
+	if g.err != nil {
+		return metricreplicator.ResultData{}, g.err
+	}
+	if err := ctx.Err(); err != nil {
+		return metricreplicator.ResultData{}, err
+	}
+	result := metricreplicator.ResultData{Description: period.Description, StartTime: period.Start, EndTime: period.End}
+	for _, p := range period.Properties {
+		result.Properties = append(result.Properties, metricreplicator.NetworkProperty{Name: p.Name, Value: p.Value})
+	}
+	size := 0.0
+	if len(period.Properties) > 0 {
+		size = float64(len(period.Properties[0].Value))
+	}
+	for _, q := range quantiles {
+		result.Records = append(result.Records, metricreplicator.RecordInfo{Chart: "phase2_duration", Unit: "ms", Quantile: q, Value: size * 10})
+	}
+	return result, nil
+}
+
+func (g fakeGrabber) Charts() []string {
+	return []string{"phase2_duration"}
+}
+
+func TestParseLiveRange(t *testing.T) {
+	period, err := ParseLiveRange("1589292280,3m,network_size=17, latency = 50ms", "")
+	require.NoError(t, err)
+	require.Equal(t, replicator.PeriodInfo{
+		Start:    time.Unix(1589292280, 0),
+		End:      time.Unix(1589292280, 0).Add(3 * time.Minute),
+		Interval: 3 * time.Minute,
+		Properties: []replicator.PeriodProperty{
+			{Name: "network_size", Value: "17"},
+			{Name: "latency", Value: "50ms"},
+		},
+		Description: DefaultLiveDescription,
+	}, period)
+
+	for _, value := range []string{"1589292280", "now,3m", "1589292280,3", "1589292280,-1m", "1589292280,3m,network_size"} {
+		_, err := ParseLiveRange(value, "")
+		require.Error(t, err, value)
+	}
+}
+
+func TestParseLiveQuantile(t *testing.T) {
+	q, err := ParseLiveQuantile(" 0.50")
+	require.NoError(t, err)
+	require.Equal(t, "0.5", q)
+
+	for _, value := range []string{"1.5", "-0.1", "NaN", "0.5) or vector(1", ""} {
+		_, err := ParseLiveQuantile(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestLiveSource_Reader(t *testing.T) {
+	cfg := Config{}
+	cfg.Live.Prometheus = "http://prometheus:9090"
+	cfg.Live.Quantiles = []string{"0.5"}
+	cfg.Live.Groups = []middleware.GroupConfig{{
+		Description: "grow",
+		Ranges: []middleware.RangeConfig{
+			{StartTime: 1000, Interval: time.Minute, Properties: []middleware.PropertyConfig{{Name: "network_size", Value: "100"}}},
+			{StartTime: 2000, Interval: time.Minute, Properties: []middleware.PropertyConfig{{Name: "network_size", Value: "5"}}},
+			{StartTime: 3000, Interval: time.Minute, Properties: []middleware.PropertyConfig{{Name: "latency", Value: "50ms"}}},
+		},
+	}}
+	source := NewLiveSource(cfg, fakeGrabber{})
+
+	data, err := source.Reader(context.Background(), source.Quantiles(), source.Periods()).ReadTemplateData()
+	require.NoError(t, err)
+	require.Equal(t, "http://prometheus:9090", data.Directory)
+	require.Equal(t, []string{"5", "100"}, data.xAxis.Data)
+	require.Len(t, data.ChartConfig, 1)
+	require.Equal(t, []interface{}{10.0, 30.0}, seriesValues(data.ChartConfig[0].Series[0]))
+	require.Equal(t, []string{"range latency_50ms.json is skipped: no property network_size"}, data.Warnings)
+
+	_, err = source.Reader(context.Background(), nil, source.Periods()).ReadTemplateData()
+	require.Error(t, err)
+
+	_, err = source.WithGrabber(fakeGrabber{err: errors.New("prometheus is down")}).Reader(context.Background(), source.Quantiles(), source.Periods()).ReadTemplateData()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "prometheus is down")
+
+	// queries of disconnected client are cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = source.Reader(ctx, source.Quantiles(), source.Periods()).ReadTemplateData()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), context.Canceled.Error())
+}
@@ -0,0 +1,166 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const MakeMarkdownErrorMessage = "Failed to make markdown summary"
+
+type deltaKey struct {
+	chart    string
+	quantile string
+	group    string
+	x        string
+}
+
+// MarkdownOptions sets links of markdown summary.
+type MarkdownOptions struct {
+	// ReportURL is a link to full html report, it is not added if empty.
+	ReportURL string
+	// ImageURL is a URL of directory with chart images, e.g. report directory.
+	ImageURL string
+	// ImageFormat is a format of chart images to embed, images are not embedded if it is empty.
+	ImageFormat string
+}
+
+// MakeMarkdown writes compact markdown summary of report, e.g. for pull request comment.
+// Values of comparison report have delta with baseline, regressions are bold.
+func MakeMarkdown(reader TemplateDataReader, wr io.Writer, opts MarkdownOptions) error {
+	c, err := reader.ReadTemplateData()
+	if err != nil {
+		return errors.Wrap(err, MakeMarkdownErrorMessage)
+	}
+
+	buf := &bytes.Buffer{}
+	writeMarkdownHeader(buf, c)
+
+	deltas := make(map[deltaKey]DeltaRow)
+	if c.Comparison != nil {
+		for _, row := range c.Comparison.Rows {
+			deltas[deltaKey{chart: row.Chart, quantile: row.Quantile, group: row.Group, x: row.X}] = row
+		}
+	}
+
+	withGroup := false
+	for _, ct := range c.ChartConfig {
+		for _, s := range ct.Series {
+			withGroup = withGroup || s.Group != ""
+		}
+	}
+
+	header := []string{"Chart", "Quantile"}
+	if withGroup {
+		header = append(header, "Series")
+	}
+	header = append(header, c.xAxis.Data...)
+	fmt.Fprintf(buf, "Values by %s:\n\n", strings.ToLower(c.xAxis.Name))
+	writeMarkdownRow(buf, header)
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeMarkdownRow(buf, separator)
+
+	for _, ct := range c.ChartConfig {
+		chart := ct.Name
+		if ct.YAxisName != "" {
+			chart += ", " + ct.YAxisName
+		}
+		for _, s := range ct.Series {
+			if s.Run == SeriesRunBaseline {
+				continue
+			}
+			row := []string{chart, s.Name}
+			if withGroup {
+				row = append(row, s.Group)
+			}
+			for i, x := range c.xAxis.Data {
+				var value *float64
+				if i < len(s.Data) {
+					value = s.Data[i]
+				}
+				row = append(row, markdownCell(value, deltas, deltaKey{chart: ct.Name, quantile: s.Name, group: s.Group, x: x}))
+			}
+			writeMarkdownRow(buf, row)
+		}
+	}
+
+	if len(c.Warnings) > 0 {
+		fmt.Fprintf(buf, "\n%d data warnings, see full report.\n", len(c.Warnings))
+	}
+	if opts.ImageFormat != "" {
+		buf.WriteString("\n")
+		for _, ct := range c.ChartConfig {
+			title := ct.Description
+			if title == "" {
+				title = ct.Name
+			}
+			fmt.Fprintf(buf, "![%s](%s/%s)\n", title, strings.TrimRight(opts.ImageURL, "/"), ChartImageFilename(ct.Name, opts.ImageFormat))
+		}
+	}
+	if opts.ReportURL != "" {
+		fmt.Fprintf(buf, "\n[Full report](%s)\n", opts.ReportURL)
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return errors.Wrap(err, MakeMarkdownErrorMessage)
+	}
+	return nil
+}
+
+func writeMarkdownHeader(buf *bytes.Buffer, c *TemplateData) {
+	fmt.Fprintf(buf, "### Consensus performance report for %s\n\n", markdownCode(c.RunName()))
+
+	if v := c.Verdict; v != nil {
+		status := "PASS"
+		if !v.Passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(buf, "Thresholds: **%s**, %d of %d checks failed", status, len(v.Failures()), len(v.Checks))
+		if len(v.Errors) > 0 {
+			fmt.Fprintf(buf, ", %d rules can't be checked", len(v.Errors))
+		}
+		buf.WriteString("\n\n")
+	}
+	if cmp := c.Comparison; cmp != nil {
+		fmt.Fprintf(buf, "Compared with baseline %s, tolerance %g%%: **%d regressions**\n\n",
+			markdownCode(cmp.Baseline), cmp.Tolerance, cmp.Regressions())
+	}
+}
+
+func markdownCell(value *float64, deltas map[deltaKey]DeltaRow, key deltaKey) string {
+	cell := formatValue(value)
+	delta, ok := deltas[key]
+	if !ok || delta.Delta == nil {
+		return cell
+	}
+	// growth from zero baseline has no percent but is still a regression
+	if delta.Percent == nil && !delta.Regression && !delta.Improvement {
+		return cell
+	}
+	cell += " (" + delta.PercentText() + ")"
+	if delta.Regression {
+		cell = "**" + cell + "**"
+	}
+	return cell
+}
+
+func markdownCode(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return "`" + s + "`"
+}
+
+func writeMarkdownRow(buf *bytes.Buffer, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = strings.Replace(c, "|", "\\|", -1)
+	}
+	buf.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// staticReader returns the same template data.
+type staticReader struct {
+	data *TemplateData
+}
+
+func (r staticReader) ReadTemplateData() (*TemplateData, error) {
+	return r.data, nil
+}
+
+func TestMakeMarkdown(t *testing.T) {
+	baseline := &TemplateData{
+		GitBranch: "master",
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", YAxisName: "ms", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(100), float(200)}},
+			}},
+		},
+		xAxis: XAxis{Name: "Nodes count", Data: []string{"5", "10"}},
+	}
+	candidate := &TemplateData{
+		GitBranch:     "feature",
+		GitCommitHash: "aabbcc",
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", YAxisName: "ms", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(100), float(300)}},
+				{Name: "0.99", Data: []*float64{nil, float(1)}},
+			}},
+		},
+		Warnings: []string{"chart phase2_duration quantile 0.99 has no value in network_size_5.json"},
+		xAxis:    XAxis{Name: "Nodes count", Data: []string{"5", "10"}},
+	}
+
+	out := &bytes.Buffer{}
+	err := MakeMarkdown(staticReader{Compare(baseline, candidate, 5)}, out, MarkdownOptions{
+		ReportURL:   "https://example.com/run/index.html",
+		ImageURL:    "https://example.com/run",
+		ImageFormat: ImageFormatPNG,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "### Consensus performance report for `feature@aabbcc`\n\n"+
+		"Compared with baseline `master`, tolerance 5%: **1 regressions**\n\n"+
+		"Values by nodes count:\n\n"+
+		"| Chart | Quantile | 5 | 10 |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| phase2_duration, ms | 0.5 | 100.00 (+0.00%) | **300.00 (+50.00%)** |\n"+
+		"| phase2_duration, ms | 0.99 | - | 1.00 |\n"+
+		"\n1 data warnings, see full report.\n"+
+		"\n![phase2_duration](https://example.com/run/chart-phase2_duration.png)\n"+
+		"\n[Full report](https://example.com/run/index.html)\n", out.String())
+}
+
+func TestMarkdownCell_ZeroBaseline(t *testing.T) {
+	chart := func(values ...*float64) *TemplateData {
+		return &TemplateData{
+			ChartConfig: []ChartTemplate{{Name: "errors", Series: []SeriesTemplate{{Name: "0.5", Data: values}}}},
+			xAxis:       XAxis{Data: []string{"5", "10", "15"}},
+		}
+	}
+	data := Compare(chart(float(0), float(0), float(2)), chart(float(3), float(0), float(0)), 5)
+	deltas := make(map[deltaKey]DeltaRow)
+	for _, row := range data.Comparison.Rows {
+		deltas[deltaKey{chart: row.Chart, quantile: row.Quantile, group: row.Group, x: row.X}] = row
+	}
+
+	key := deltaKey{chart: "errors", quantile: "0.5", x: "5"}
+	require.Equal(t, "**3.00 (+inf%)**", markdownCell(float(3), deltas, key))
+	key.x = "10"
+	require.Equal(t, "0.00", markdownCell(float(0), deltas, key))
+	key.x = "15"
+	require.Equal(t, "0.00 (-100.00%)", markdownCell(float(0), deltas, key))
+}
+
+func TestWebdavClient_ReportURL(t *testing.T) {
+	client := newTestClient(memFS{}, "consensus/master")
+	client.cfg.Webdav.Host = "https://webdav.yandex.ru/"
+	require.Equal(t, "https://webdav.yandex.ru/consensus/master/index.html", client.ReportURL())
+	require.Equal(t, "https://webdav.yandex.ru/consensus/master", client.DirectoryURL())
+}
@@ -9,4 +9,4 @@ import (
 	"github.com/markbates/pkger/pkging/mem"
 )
 
-var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7a6f6fe2bcd2f75739cadb659b10feb4a9f4bc28b40428a52db404b25aad1cdb242e8e9d2b4e807069bffb233b010205b697ceb95fdc3ab756219ef1783c1ecffc3ccef66f8db03917daeddf9a7cee49acdd6afabbc0b1d0431ef30d5fea9023acfb5c1731d47d9204a9770579a813263805b10e39139889547c8f71c4e3446815ad17cad60b4802ed56d32ada108458bbd54e89de7398cbbc81d8c749de1e715eb49e400203ed96a59456b4710228d66ee7800a5c50230c0467b9accd3b8462b195ce8dd891f738dab5dfb0488ea425eb68c41347a99cef6fad58c91757ff0408d36e9338c595ff98476dfec4d1bfaf49f7f955c8915238c1b120ca77d5ab6a5dfbfdfb77459be72ef9fb4b4bbdd5a385afe7849ee030a220c157411252a94106967c239c004295529687c1a16845136483b5db9ad9b8ae68214758bbadd79aaaf92b216a846998c6f7aaf1ddb879338ddb5af3b6d6bc6a36af6f6a55d3aa7f336ab786a15534227e21e9ee223e44a626bdc74bedb6dabcbebe364d436daa9cac661af58a36a4842db4db6a45eb31aeddd6ebf5aa59ab5d57b47782b4db8651ad68b66cc9812324151915ed05a05fd0e7bf0cedf6875151ff7e56b43b69aa883094738ee5abda34cceaf54db36a56b4a1909c46f3c6b48c6ba3f1bba23d9d936f58cdadfc6e89bf2b5afb1fcab7489c049fc734aceab575ddac1b3b9b6e1a96d9a8dffcae68e3d23eb428870ba1dddec8e622dfa1ba21a7ea50e00be5081b33f51e8808c458355f8ba674cc4f1551018eff63c8528ab76390f9e7c30b4cdad19f81e88cb5520b02f18ab05f2044cdfa3f35fd0aec11eef4147bdcfba15d693f77c09743ca21ee0949fd0be108338419cc6eff5532476653ccbefb5c1a04d338c62c2903e58f62f9576ace15f61058caf6cf127afed030831c11e6eb1f72cacad77d1de2242630c6112510243cfe4763094214af6434fd8351e7e60a41bcf0408295143eea9403711cf3581cf2459222c2bf7b5553f779ee1c89325cfde884a70991f0c5e5b04886614513792c8924869c2df31661be9448f07a8f92d2c3f981f443f3d2b9d2e9650996829087518c85d0e74ab2c4f0372452344b006138d6291149c1c06bd58ab328e1bb860e728d39014914a8b517342a772201f604868724321b8daaf589a11396e09801aa63b4023112c7629492282170cf094250a276c363c050e1cde32e917a09c5fb8e1035f6841c57a260bd4494172002503da0cc46f3806e54cd127d3465424b7e5a370ceb90dadb1a02c88f44f56841d65a659743a5a60e04ab96690f08dcac1f7008037156e640b12c93012e2bdf26e88e8e705826d7eab8dd85fa9c0269cf3c4c0ee37e2e184fc83cdb350ebb7dfe3da220f3639e32a4530e01c5e20b227a0e40f0a2ba9491258e05a0df931830413fa7b2cfbd743e0794eb013e0687008880401e477a00e9852ef50091fc492402b138468b1352b18a803f89090818fb82d80e3b2e8a257c81d92521190b5f588012fb82694aeec4a4ff5b4e80ed28c204e46c4efcc36eb9bcef24c1b11c991fc1a55e8a392288cb584d63767cb4f804720648ac47318f709c90e374b878fa1c759e88ea638912de44976792bf39e8fc51461709e2c7da4802034c69a0664ae21426e927e34e97182725623ca71826e6d1f18b29c509c1d2eddf131ed2af9dce51cc439c0438153aa404b3e497cf2990b01a91af4b963b97d5f3e3781872a69672649e88e6d59a0ee638e6673bf450e1f0a74ef8098272fec70ad004c72b0c9200c72160a784a202be3f752c49741c63a72b99b240ea71411219e109cec147b9e88ac7bebed645262432af8ff9794d23817acee3f0646fca88aa6ad956205af857841dc1fe12508264ea5d2dadb21461e42adf962d270321bd5aca1092f854bc7418c39ae2e5374af9dad75a156d9731b2c602d42fb3609496c979981445dc8ec57092c4001e28e242c5649915714acbf4f19022fa2939344910e6533ca7c40f0e6615998080521daf31ccb7e45357b1253b7e824542f9c1ea5226c01c07182015139f8ad75c3cccab57f9d23de2ef9beadb836a17a556282fe4f94b0f539a9008286729c65f294f3052c721f05405c57092ffea419244a5a6fad97a75c7dc9a5df0640045315705ada4d378576f73a11cb3afbce54b9f138a0bbaf0b66af9781ded1abac85802a4dfe29425f9728a960e15f06f2984bdd42fd13ba7828487aaaafdd45338f2135f64e2f2ed40640c16afbdfa629f779707e0ad6292571a87b789233a3ff625375f5e9183fb969e26f36af390be51a48c16ada22d3143ea1c2ca5f3f61611001800d3f89a54c46956ad198d3f48ab974cceafca6d8be74bc269bcc4db1bc905b96081e697253e5f462e08ff61c5328e1113f209b110c03fa7ee2053fc5465e21fe5a298afb33f089a7a1001b8b82045100317ba639e9edd287952e4887aaa5745a9c0308db1ee1144e2f4ac373f1d2d6785b6312c157e454e9d443f8f3e02ffc8bf7eac7e490c2d3e811c7c12fef1ef17ae228971028358e1b4bc5f0121b04204c550759acad89fff7ddf9e0b1c97eb5df85f5f6ef111effc87e8df150d810468b7da4bbbf3f0660cdf9d49abd3b303c37356dfda1f6b8eecaae8d9ebc00ba1d50b2769efa3fef8620774e6bc7e6b933bbfd7bebbf19c89311bb73e80ddc9a039315ec6fdc9e461963cb77b3b196453c3b3dfbfbd9a560acd490a6b934dafdba230749730ac065e38a4bd6e9f42dbcad0fd6ab995df8e87e63003d396d1eb0eb3d9c620a03b3260f7a939c8ac0fd76efc05b3c687675b55d776c56cdaa78370b8f4c65600c24e7d60aea3197b5abace90cfa67d036637c6e0c34f9fda169d994100d968337026e9208c36bd8ffacda036fc80215da1fbfae36efedaa851b2473db3d05ae071ebbacc538fed2e3df69ab8612701cebaf14c5a9b99d3d80ca6430a431a3eaff85eb66b3ceec7b6f8d378e583fb9ee8d9fea6d7158f077adb773eb22775d4ae069e4d99f7c1fd993949913dc9ceebbcfbd8aef1943ed7a61b68af03fcc67d375c537c7f4246f2dbd50598f6e9ac368a3cb3deec75fb4bb4392b5b836167f54c5af9fbbc6d29988ea877d28701050ee2e89efbc3fb96094ed8e5399dfac029cbddad60d7ffacab8b22b73be2cfe4aefad47e3f3157359036036764807bee3fdfbf9ed6635ba63bed879e6dd59e498b0367b4709dfa81dcfc355feb4bdbdaa02e15ee5bfdf1a56d71d7e92c5e7c7ea36246f2ec518448ebc3b33b1bb831c8ccb4526477222f9c6432c794befb357fdad4cbf171ef998d8deb343668fae4437b92b9a195794e279d99ef3e0c272bafd63764fc41d22230947cbfecb79bd9b865cca67de64e5fad1eb58867775290f57cd0ed53f7e320af18988e38727ae9ccb492814953685a6236ed2d67d3e1064dfbd46d5785eb8c983bed2d911c3fb6ae71d6e882e9a89dcf1d58f371ef1bae89746452e395f5659ef3f9d4b819980fdf0677a5b8b0874bcfa946e87fd04629e339d418d492ebc10245e8a198f32108e0b1ad85ecd8ee6c40b76abdb4ade0c5dfeded7e5feed70b309df9c079b57ae1165f7adf5eda96e47f6b7fac96ae4dcd179f3fbe74f7b8b25b331b1a3dbb1867487a1400a74121b9b37af66839ab4d12d76918831051f43011ae53a51e1bb5f13b5d3cb691c25cd47d628f6fa2c0aad6878c11d46e6ddc693f72a7c3e79953a5bd7bc37fec3e44bd7be3db210eb42297dc7138beb35ede0cbf477ad1299c80e1c440d37edaeb3e9c8cf73ccffa14752799475aeb5e5bf8fdacb5464e27453615ee1816e35a56bbf0a38c79587bf567ce68f1aa30ead57f19dff102af1e66d3513068b7eaafd32082d9c27f79abfb78751433ca7fd60ad974e9499f9db07de63422cfe918c0b1d267d20a67ce7ae38e5727b0559d39cd533af2f9e8e299e4e71c7a1805c87e48bdb0939cd295fb634471f7b5b9dddf91dd3166e3c6c29d1671a06c3e33b67db3ec7587d5192b74b4d1d138283ee153be9fc2755ce399dcb1edd9d02ffb2c97b10e722fe7199e6909645389db9fb1af90812165ae39c9947eb52f27ece81a2778a3a567ae8957f32ff8d7a5b3e9a80ac30b7b90dbb1994d5dfa3a1df63da7c3dc37eee3acb501392e1b904de8491b0ecf0e069ce13b9eb6e8f9f51ed8df8032363ac2ef9bebc80bdfd9a07dc7666127eb672bbf5f1b1a336718f7b316baa46b7e2e56f27565ee746878b5beb2a9d71d72af069bbdee2843cefb61aeb5cfe4e0f9fd15ae83a817be9ef76d77b484f7dcef6f7ad57e76c64e5bc5fd714c1758f3947a4e67f5d87d50b90acd49069cc9e64de548b01e848dc073dea3c7afd99be7fd861fe67c790fa55eb9f70f9d3a90735fca455bce3d79f2cc61a0f2e783fb7d7328b194c2f3b9b49018f44cb618d450b4ca4dbb4151172d61287c68d2a63b7dfa34efa9bdc6efcad64b7b90c7591b9933675d75c7676d4b25ae1fe351ae3fc7fcafe5a5aa49a9f4f301eeb2829f3592d9b4381fcee342e6aabcad9fddabfd1ec8dc2e741b8ae670dc506b79bc9c1b469eab77ccb3697ade2ff9e3cab87ce33e1ce77b3668df2d07592b71a7a30c384ff23cfbf06afd45afeb065e774273bc1d2d5dfba67949ef20bb91d89101436180dc47c543b6b5f0365cea5df4e41dc48117d6226dc9dbd0a4429eef5bdcfa9c07e5e766b9cde599d3a79eccd59aac715aa16777d8d99c3d9a73eb1ba79634b639f6f476b79a8f573eaed116b6e9e699dc6d9eee5bd6f40ce6ecec39ace7cbb176221f16e5bac37a5ef132bdad5bb6f11ca14f35d030739d8ee1be6feba057de37a51c8c9e0feb82029ba829e3183d740cd4ed47333631dcb1cf80f3ca0645fec8baa96cc37e6e8969a316eaca78e95791fdcefbe650c8ba52e1bd490dd731d8f1f8fd1aa870dbd2fe53b55d2380dd16f5c2d17d2e1794e65d5c6feba3622d0735a2ace3f6f7d846e485d4782cadf7e47ada6a2d2fb0abee6edcabb5542db493ed1ac7b5e35fd0b45279577743cae49c633a7c79238dd56cdadf481fca3a39afd946f24e12020759f3f17e1fb6b55d81cdfecbb8f5f86e58cf03d60a201bd2c736927785998abf5ad5ea678bad0d07730f42770917936066fa455df8aace9652cdb8e58b5e3750b1fb385e3cb67d7e788fff582d7777afb6a5be6bbcf8fcffa96fe3ea7fe5761f57f61f4eb4eddf68e1fffbf3cffffa3ffffcff000000ffff010000ffffdac7bdf90d2c0000`)))
+var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7c6d73ea3893e85fd9e2ebc91c6c03499caafd1020d826401248fc36353525cbc216c82f8f6503666afefb2dc936b189e16476f7d6bdf5d47ef044ea6e49ad56bfa9c59cbf3a385c47b4f3f057877d639c741e3add248ad26e10b919419d9b8e16c45192be82d4ef3c743a379d050850e7a173c28f235820de41e2a1b4682fa3a86ccd410afdce43981172d359a580a0cec31a108acade12011a8505ad124d3041b4a22e563e75c7283eb5df114dcfa819e86cc4bce0f1e1af4ec9be87533f737ec228e8e2904604245d1885148534a3bf25a8187cd399031c761ed2244337ed3251a279e49e81bb5ef433885c8ed5514231df95f853ec77fefefbef9bcebad8da5fdf62e2a11b6fbd6ec1513745414c408a7efa6940d80cecd0d85f17a500137e7c61712a4dd29b0ec547d479b81b48d20d3b31d479e84b026ffe99623e4212a4dbdf44e137517e177b0fbdc18378fbb327ddf7eeeea5fe9dddb9e960faa78b93d3a1d19caf3746bbcec3ed4090fa371d2d8c3a0fa228f6c5bbdb9bce82e070db79106f3a73be60af27dedfdf743eb0db79106e3a4af9d7fcf3cf18b8026f2f5d369b70d359d5d81d926dc17d5f906f6f3a4312c12ded3c88b7379dc714078c8915829d07f14e96fae2adcc100bca203d491edc0b77b7f77fdf74e6bf222d77faf74d67f47d52f3cf3fb330a3c8ed3cfc2edc0837c21ffc887d94b49b51ed34cf2dea3bdad01c5e1ae0a97fcd00eb4c7c1ae3ef9d9f9d3f4ed658e879d3189d0c13f73fb4f17f0498067c50cd3a7fef8018fff4a2ce4d07508a525ab49d24da5354b4a18fe0b66c46842098569d2006494514057114a2b09a001dd88e8a360e8057926db21097508277253000c9d68df661d12b6451b63382ca09294a70ad5d8d4d5112e01090b297a0d02d9a7be4b86057b40fe080f9d03f6a8ee8f78e93a788b9081885293ab0e340218c5c1c7a5d4877f5ee8649b2d63f70835c076cccb70f3d406982618262822148a3e41f8dc5ae4bd01e24e89f8cfaafad45d32801ded942ec8c1c90224e82cee663a35092440993a61711107a3fa3c4eb1ebafce8bbeb284c2f63ba0ea0185ea60940ea77d7f880983ff6a278ebfdc461370701f9b9933a371de6494f7eb552b7ea6f1746846fbf98cb4dc0fed489438fb59986e0a88ba32cc5ec5849c4c06cd5ce4d27625b8a8b36fbd365aebfec27c8438798e96661cc344d601432c5a16982438f0d658ad54d81b34f70cac5c65df54d270b318c5cd4cdd2f53db3df2220fede71b23567e8a49b415c5a578228edaecb3d9e00de1137088e043ba54a031ca2a44b304d1b3a0e933c4ea353a30b0a1be0d02ec431737ca7be5b47ba147c761074fd46af8174a5c140946b0042709c62f80959e3988a7de113e06fdd75ad17801ab11f6fd1670f87294a4240ba4ec4c47c11d1751c7c054b5b91cc265210a6e5399da3519826519c7777e24fe1a7d042f0655fe798a6c0dbb05d0f06d7280806d76670b057e42e9708b843bf827713c7bb826e9e7c1b9a826bf873dd68a1d883c4a5ff84acbbc6885cdb7353bbbea21beaf6051d90eb7b0ac8165d3bb210d3145d5ba020e8ae3148af50255799a03e9006b7d7097ad7d10351ba469039294157085242af4ec0f057388000fa57a677514cbbcc2f46898b925fd0c138fb058517b9c8c9ae283aa7bae0064a121fd02ba61085246fc1e220262de004846d0accc065603a47d19c360705eea0d669eaec998a360726b05febd487511f888d5e43c59a1a75ae40e7fa92929adb4a09fd22b006c16120d4ac9ff5baf1161f6a69583d23033414eb7d0750d493ce21b7fd06048720c9afa47d3e3a5ccb02595678dac345041fb626c0a3d749a238fd05c51e27e80bc5869e227b13b16bec3e4641bd5b24b0a79c8db1d796d0ae6918a5789d9f1a4db417fd1613907b4994856e974410b03bc5af49ba304b1214c2fc1a6d16e21d4a2820bfa5090829f99ac67a9193add780445d1f9d27c6cc32318c92b8eb437205c53f40d35f91c420a128f92555c20fec5764148230fc06d9299fbc4a96465b145e23622af28d0d70b26fb0c6e95a16fd37befd544370486114aeb1d7443389fcc6f27bb658d78b9a58822217bb1153ef2c399359003c0ca310e0a41b27518c921423fa0f6e5e67c8164338a738799400c4d75762ff2dbcec2f69ba3475a3f3d9700a7d4488cf574a930ca6d917e6221725e16f5ec42eb0855338b3c44f8a04ad59ed416ae26344084a314a9878d32820d76ea63578120528f55146bb906014a67f1697d32e88f1f729ebc89d78795c140451c84a8be88c3d1aafc55e17ac51125d44740314b421e117af55c0377b405294ec11487d9404e04ce30aa2b8f4f85f103b1c9feb184d331747bf39a2d4f5a2a2ac7246903911c529d3f01485bbf65bfcff680da086a739ed66213e9cc3594cecf2c8b18e92a0155bddc1c38aa02c2d34e3d00e10ec32c3feb993eb5438c43f77621d522b4914792173e95de01294f42a681726b057552dfe3bc58b931df36e992136ea1a15017070a34b4158ef3b9822983620798a0069cc514fbb4f40e803e883fb3295fc04473bc40a48dd2485d1ae8189b37ab72aa5109ca2063c48cb8aca09e44520817e1352a5efe720da84a1438c121c149ea5068f1a74c199544294a609800dbe22ca5d491d14478434fa49c476952018250da19ccf557ab3f3ad2759c86e1c5d904601866d18c8d2a3b80d830e38f5a368db86f35ae7f2208ff76da8323ab4c053bf0d1ec749b4ee12e020d286662f0ded600808e9121c66873a01653e11470d100e3d82d6047b7ee3243fab6e75102bbf9d0b97e661430cac9f22da9cade4081d1044e1ae0d557a9b139c4d51d40c3f41ecb88bffeea43a220bd9ce7c044a532a6b8f6b7a56832c8b85c5b424f24ee6ce2b9331fd2c5072cf58d4b9ca665a61ab2be5a9dde5cc04c59596fde9061949710cb8b171c0bfb228452ecf6381c32ffb2162c810a55d3f4de35a93f72b2339016b8c7e8175018518b762584fba882922e845345def4a5c88525cf1c822409c44bcd6c9705942aa826e44f9015f2bed72f3ac17798b6a6f97e6610a98ae963afcd9eac2f2cd82c3bb957ba2044344af57894bcd647f3e4dbfd4b7d62232077c468d46bf48f5bf949a3f5bace82cde36fbf745f75f5931826969e7a6b343a1cb33da5ae82c2fe485f39784ef51c511c9c59e30f805359f9ac59aefd255f7fe2bc4274da9eaa6dfa1fd05bf4c9ddc9076dd900688d2e2be7089f0642b5e96d2efd0c54974c87f412875fd18c0ed152aec86e0029ae54c4581ac0dcb9589229825a8eb601727c53bf945d27a927591a8523536e177e8ca9c6c8fc0b6f3c7d96f04f89be59fccedd61f2b6b005ee0aef78b97ca3348f15c5903166f9635004fa76a7dfe7a59ebb327cc5ab77ac7ac818a9b651dc05e346b7dfe9459eb57ef9975107bd4acf5790abe3f01fe38fbb9c4d577cd7fd387cc0bf7f24bb73f9a2628857ed2653264e52df6f49da4bf204ad0bfb2a202d748b36b51fbda63e1a7b3e78bf27b358b139d3ffe7ffa6d4bc9fec35f9dd7adf77d46ca9f315cfea1cbdf371d17a4a0f3d0791d4d9ede85c587a10f279ae20b8eb1ff31da1c225711a9a61c7c2780b216e899b6e93fbf2a3eb18cb71f23fce869a3c77bc7d0056b35dc0065924349175e57535d7fb2d2979176a271152238cac78f3749cea0a467b0a71f3575486060ef6020fa4eb0209a3a2550917377bcdf55f4c5f8e11d9248a08d0663b686fb7488ad70eac390c8ebb788cdefbdaa8b0d0cc8de1d0fee50de42f7bedf416991037328bc7ac518d44b89a32ec8da149e595f1b1f8e566f1a43f5cd83bde9e675356d9b4bffd81ee4f54afbf13a922bfa1a9f7a66aba23c2ad778551702320ea45a937fca7467ab5b0fed6b30061f0d03471a0833c3f61d8350f41e79509a6430178fb6398ded0d7daed1caa56c791f8c9fa8a678f96c348ce6f9f0ae8ee39fba24487d4b2de310db52ff565316c40997045e9953931691654e05d8369fb28c616f482d737bab2936b54daf8586c3535b21b96d2c0460c8d90b1ee64e0f5ea4757b531f8e23aff87b99b759e8fa3018c44ee01e35b54e577c962453a7a7dd6aa3057e795ffa8b7193bf4a6f8a6f20d9e6740b8c85a0a94bdf0a0ea46d4ec798e4b644b217fcb8d79489e82af7df9a73160e7dd85b10fbd2d9288b9da3c8f90b7edc2cc656ff65fcd67ba9eb865aea276b8f1e3337d0735b211b7734082c8350db786bd3a58d231d767013799aa40d16ca93f0724d9ec162e798431f06e4e8487dcf5526d8513edae64d2d731a02a37fab8d1f3dcbd405e7f855565620e7b6a2e73383edede04375415e30e7895ae6f068bfff135edea8361a6c1c49dc5be63486929c69ea326a3b23a84cb6b64232fb1879f370d8d7c6fe1e8daff137ddb9ea72e7bc47dedc1cf63595f14bb6da68b1b595b75fdb913a8d6dc917ae9d9725c92954263930173b073f6630d04318e84728919d83afeb83b5d90ad6fb5bffe592af305d020c3772c711db13b5ffc119cf02924275ba73033db58d8170dd8ee6c262e3f51763784de76357d1d3b67980a21772c28fc25cb5a396fda496e9a50dbaf1b0f5ec5c896c5dc5bbd5c61ffbe97bab9df667a61bdbea327ac18ffdc585799c9ecde20f757af05653fcd85696c469f787f7b3dec98fdfcfa432fe6d0ed891960306b3152269ca825ae6e2f8ba9a6ed8d95806c96c53c3b57813dbf8317be3fa20674ce6b0279ed600e3f9f7e2238b133dcd433d9a7d847af61e4c52db14e5d7911ccd8ffd2a96315fb37194c9111e056cd5d7cc4f71b9e22bb78c4168af1eb760b5f7a6cadc7bd908de6ccbed70e7047ae6aa0b796da677c0b0bca9c2e22965e388132c4f3198cda5a9e9dd54999f782af7bcb5f2c1080693cc927c79bd1ae2a2edd5655dc6f8e566b69d327e3660a2afde55517e559e983fcaed22b7585b01cb4728d6143fb70d4bd6c262cdb770cae414e9fa74be3605fc7a065f9bc2fd4c7afaf119f7c53b94d7d65345f9b42733bdb38dc196d39cf85aec1c438c5d559467dcbf8a2930df2eeca1a4fda77b90e4d4318850ed415397b1ab1c48918b3479d0362cd7596e66641139bda9508dfdba4fe11ef686be8387023097d47e1730ea517626e3620f4b9ecf94b077c074ece9731d96e7406592bd86759994794e538f1afad0d047958d3d1cedd530b6f17007f163b69488509d8d361aa8c05c56fc6896b9886a7959343f0ec68e3438dac6e0e89a730f2a7a6e0772ee184c8f3e3c18e87b26034db177109ff48bdb487d9db52950ee5fd939b2f3ca5bd7bd9f49deb166b7951cffabfcb07556ae3178b10c91b4ce5f931beaa53d602e236d3430aa1ca2b6f61698966749071ff6e6b216321fb68caddedbc9bf7c309b9774a6bb7be68f6c63c9f2520a255d5e1bb60f8c03b1d5ea2c85937faaededb4ee0bae747af056ce25aff5e1f0435c7ccef9610f571f87934ecc985ca50361b16e194c6247d573db5c30bbdfd9f813f7c6fca8941ed7a6e059655b534afec6fb1df387677e8aef6f694e73a7373d9e64322aef18c6be415fe91dd3055bfaf0665b3d87819c339ffbaa1c62a6cfb390eb3705effde7c6b89673e1707637395ba7954e4def5c890860742683c65c07c132a6d46e59fb555de6af9b8300c6e5fd83adab783f5ed565f44a26a2650c0426a7d7912c8071ffde55bc1f1f929e03433fd66146639ced3baade18f3ae10e6c3ee67bd65f4bad9ef5c55bb2e43262fc65b5d676adfabbadc72b96e8bbb03d7f5de72cbf6621777b3a56b4c325721b48c5982cd7979fbc16db537ddb9e6505ebf33bedf7e9ce6237e0bacd88faee87d57fd3a57b1b7e5876dfa9f7c7ce59bc15bf653bbcbd5e4c1d6f87a665f68ef67d232763725cda7ad9dfa2c8ecec8e71de6b27dd7ee399b7e43df997de8d224770292d9bd79c3a7947389ceb855afab789f7d8e5fc8ebf70305effc8cb8ac1d65dbaa0b8dbd9c64a8d36aedd791cc7c5439f6ab6c8a5c896c599ec4755b9d63a67b0cfeea7df181e37ade7f514ecddcb6cda735e6d11439607a3ddb2e7c2758c6b63211983e6aaa1bbb8ae759c1e4681b87d8093e780c793bf57579bdda7bae2253db9c9679597a3723cb9da3e84cae1b36cf74b5f71a797e38bf2de8a6c4ee4d09ec2d6216cfce63c1ff13bfb09dfa50d22930068d716fd224b315b2b5cc6503be5474ea2a4fa5cfe0bc3cd9c641b058eef85ece3b9205b8b9ae7b1f81dce3be585de6b5dcb98a372bdb7059dc380243ced6a698d7fb554cd614969b0da68ec9ea0a36710c96ab8a3230c43d0c64c9364456e738e9a0d6c2532573ee9f8ab59f6cb3ac272964e7842c2fa4d952d1cb7acf929d5d99231e8eecbc19fe4df27d18b6e58e03dd09785e460b1dd03360d6e9fee77de417bb2dedb49aa3a6cf4dfff8c9c3d832065b602c7d57b948539cbbce6a4bcb167e587d8fd7999ae3bde80b5fff2d1f3cfee23baa787cf2c3a3cda9b657f92576473bbaa361e98316e3da9dcd7b5d0db78eb410b93e8d06a16d2e278e52dc93df4232b5475e6815e77d9ce6dbbbaade67b1fb5befcd83a5cdf17bd0ea51d646fef179f528bf9eddf74ff49c87395bd7319bb51196f344301f642c77d4464ff2ebea116bb8b57ec8fc9b00f3c1de3517d1b33acf9c62fd441b410f9abaef84cbd8513ec2e7e61a0df9b2d8c4c6da3c163fc66df77956cf70d5790655fd0846fe7116b8b9d3d3f7df9917867af6bc6aa97ba8432ecf593814a1e4b13de7aed18f2fd75686b96d2e4518f44ffc80408e1dec85b3d1e3e71e55e1b95c6b37cb8714b0dcff89e93ebf3708ae39cd60ceeb5a89a61470160fac225f2de8c791e74803a229fe0e06e4b6d095bde7b05a5371a761f78918e6a55e2993cc1ded4b5eef779a220b40d1735722ec0e74b40d97eb12cc87d836973ddbd0334df552cbf4d91c92651c44db9c3f9fea41e142e0bc194478e76b729da2d774ca0d26d435f4a336163c6734a8e6ccec805057d1f367d5f25edffb9e8b1ffdd777c173429d3aa36d63aee2cc8a3c0be603c26a8f68e54bda58f8a1f1f182ac557891eecdd5b65d5f4a399f68f301b58d41c862ed6b3e74503ee4fafd828774160c7cc7f8a09a4a864821c7175cc963e1cc2782b7d605ef057fb113b94d0650d2436ec3edb6c5eb28cfca81b0b363729abf530fac1eef35659f55f29b0507e204ae00c60c479356dde571c8a7b3b0dc9f98c6da48f0e6bae069ef82ac2903d151f6de145bb5b35838a0896f951d9f5f5d105be2b1ec641f468f7adcbedf233627975f519790737b1c7986b4f566abc7c36c346ce14b743555a09a4a99ac763078bbd526697c4e6bea5cde4d1b6c9177f3ee3325aeaae70eaee9f9f1dc164ffed7b7956571c719719d2efcf1138b3b4f54537dae03d7f4dc29f27caee7a54f7eb2cca53f0b0a7b81f9f07e3dfa72f6d5fabb229ef7bdd7d6739d64c0107d9e076c22cf0e26144a1ff40b5d59a77939e3f1f31bc6ec4c9afc157a7e815e603193d5cc6bf2c8ec460ed2bf3096fbb9a36b4ccb391ec3b371e1ecf1fc3ccb9aa8a207ac963c95caf7210cbfe8e37ab5ff02731579e7a8cb98bddd7c8d4b250daf95bb84bd494ca5491f98f3163e86720b4c7024995a81dcbf22dfc03626ccc790cb34a52f922692fd3139ae0cd1b7a58f5b660350f277ee31f25c752adaab361e6a9fc2c6918dae92bdfd7e79bf9fdf5040e690f1e5682397e7d9d37cef4da5a90f31a4dac865f1620372e8996f51cbf8f253854be75df9d8a3abc839e7291f1e8122f75ef05080a14ecede242ec4d3cbe7eb287ac872d52bf2179cdee3ad3682f962052ff039647709ff5ca7ab5c6916883e1cf98722b62c080c088102b79108aeb6f1087f431ea5bfb8c2671567fa6f3cd6967ef3c27973fffa71d859d2a47cbf7c0c2d49cf58fc9ce65fe554be8d32df755bf9ae59c0f7e9cdf27bcf6171509273c0cec78c09dc7f4bff079cd7e335db2a7471da2b7cf765deb83cd9fb2fafcf2c799e3328e62f64fd2d7da8ceec7b6759f7bb8f6d71e2c259d5cee03d3acba9fdc3730b5f5f6dee312c723448afaec168f4e28d87e98ea62e075061bee189e7abda5860797758ab0b84daf8de9b4a4b1f4a3eb147d0636b4149a6c0780b5b75b3f6f1fc92ed6935e036312b7c766a9bcb1c187366cb1ba737dd56f9d2b5b9985ec140dfb27778cb80454e6ace3d47b23c97bd2f988bc8910e5b4d9d8a8ea21fd9d9dbc62201c62064efa83c1f65bf2fa8f2cdd6afc88f79eef93d9f77647508277cbbd5d465ee5e8c73b54f5d0c1c63ba7378fc19ec988caffa613e7e482d634ad81894b377b1696a994bc136d99ba57b6785c22fcf82cfc572eb407f7755424bff797a3f9fe68bcd7c3317e7c7a77056d32b5b910557d1b7cc977fbe496aadf672e9fcabdc6516d8b1a32e09c43e2d6ca6d4bbd1d357bd1b5901cb4f6bf7b9320f7faaee7bc1143f46ce6810c24016e188e53f8fe134df167423ff50ddd734f570af8d60f8bcdafe92e7f28d37b5ccc7a8e47bce62d9f36a90dbc652b4a48fe85999506754dd8bd87a7dcf320e94bfe9070bdf1df93cfe3daff69e210a5f7dfae8424e79153e949feb305538ddc79bf7a7cae711767ff16c45deb8bc3ef49659bd296175ae25d359765f187921ab294cf3e67da8cadf8069b1775ae1cd5c0a3020d83597e479e4c6f608d2d2c73673e6dadab37041dca789e0aad3d80a75c15eb15a02af63f27b2b3097c459c1f3f1a73d00e3b0ade5ba47f666ec2a13a6bff92c98eca1a267f65341f75c5b97dd879b7b29f2e7629e3796ff926ace59403260be4565ff099856eb7e8abdc87b9e1f63bfcaa54fb4ebb7e8ec0e1b1f1d89bfe505c0806ccdda6fac988ed8b1dd13e56a7cc5237ae2f962456ff17855d095f337e6cdd8dbe0d2986c40759f18f1fb43edae51c1f7d5dcf1f35bf43cda347e27f6fc3a924f6ffc33a9fc5d9cf79fffd9613fc94bd8ffbd72fa51dee70fee3ad53f5584fef79ffcfabffa4f7efd1f000000ffff03000082e12a5d4d0000`)))
@@ -1,10 +1,10 @@
 package report
 
 import (
-	"encoding/json"
+	"html/template"
 	"io"
 	"io/ioutil"
-	"text/template"
+	"path/filepath"
 
 	"github.com/markbates/pkger"
 	"github.com/pkg/errors"
@@ -14,12 +14,19 @@ const MakeReportErrorMessage = "Failed to make report"
 
 type XAxis struct {
 	Name string `json:"name"`
-	Data []int  `json:"data"`
+	// Property is a range property of data files the axis is made of, it is empty for trend of runs.
+	Property string   `json:"property,omitempty"`
+	Data     []string `json:"data"`
 }
 
 type SeriesTemplate struct {
-	Name string    `json:"name"`
-	Data []float64 `json:"data"`
+	Name string `json:"name"`
+	// Group is a label of series property value if series are split by it.
+	Group string `json:"group,omitempty"`
+	// Run is baseline or candidate for series of comparison report.
+	Run string `json:"run,omitempty"`
+	// Data is aligned with x-axis, missing values are nil.
+	Data []*float64 `json:"data"`
 }
 
 type ChartTemplate struct {
@@ -27,65 +34,187 @@ type ChartTemplate struct {
 	Description string           `json:"description"`
 	Series      []SeriesTemplate `json:"series"`
 	YAxisName   string           `json:"yAxisName"`
+	// Limits are red flag lines of threshold rules.
+	Limits []LimitTemplate `json:"limits,omitempty"`
+	// Metric is a chart name of data files if it differs from Name, e.g. for panels.
+	Metric string `json:"-"`
 }
 
 // TemplateData passes to template
 type TemplateData struct {
 	GitBranch     string
 	GitCommitHash string
-	ChartConfig   []ChartTemplate
-	xAxis         XAxis
+	// Directory is a remote directory of run.
+	Directory string
+	// Components are repositories of run with links.
+	Components  []ComponentTemplate
+	ChartConfig []ChartTemplate
+	// Warnings are data problems found while collecting charts, e.g. missing records.
+	Warnings []string
+	// Comparison is set for report comparing two runs.
+	Comparison *Comparison
+	// Verdict is set if report is checked with threshold rules.
+	Verdict *Verdict
+	xAxis   XAxis
+}
+
+// RunName returns short name of run for report labels.
+func (d TemplateData) RunName() string {
+	hash := d.GitCommitHash
+	if len(hash) > 8 {
+		hash = hash[:8]
+	}
+	switch {
+	case d.GitBranch != "" && hash != "":
+		return d.GitBranch + "@" + hash
+	case d.GitBranch != "":
+		return d.GitBranch
+	case hash != "":
+		return hash
+	}
+	return d.Directory
 }
 
 type TemplateDataReader interface {
 	ReadTemplateData() (*TemplateData, error)
 }
 
-func mustMarshall(v interface{}) string {
-	buf, err := json.Marshal(v)
-	if err != nil {
-		panic(err)
+// CachedReader reads template data once, so several outputs can be made from the same data.
+type CachedReader struct {
+	Reader TemplateDataReader
+	data   *TemplateData
+	err    error
+	read   bool
+}
+
+func (r *CachedReader) ReadTemplateData() (*TemplateData, error) {
+	if !r.read {
+		r.data, r.err = r.Reader.ReadTemplateData()
+		r.read = true
 	}
-	return string(buf)
+	return r.data, r.err
 }
 
-func MakeReport(reader TemplateDataReader, wr io.Writer) error {
+// Options changes report rendering.
+type Options struct {
+	// CDN keeps link to the chart library instead of inlining bundled one, so report is smaller.
+	CDN bool
+	// TemplateDir is a directory with user templates, its template.html replaces bundled one.
+	// All html files of directory are parsed, so they can define templates used by template.html.
+	TemplateDir string
+}
+
+// Validate checks that bundled chart library is available unless CDN is set.
+func (opts Options) Validate() error {
+	if opts.CDN || chartLibraryBundled() {
+		return nil
+	}
+	return errors.New("chart library is not bundled, build with `make report` or set cdn option")
+}
+
+// TemplateConfig sets user templates of html report.
+type TemplateConfig struct {
+	Dir string `mapstructure:"dir"`
+}
+
+// ReportTemplateName is a name of html report template, bundled or in user template directory.
+const ReportTemplateName = "template.html"
+
+// HTMLTemplateData is passed to html report template, it is a contract for user templates.
+// Values are escaped by context, so charts can be used in script as {{.ChartConfig}} and
+// they are encoded as json there.
+type HTMLTemplateData struct {
+	// GitBranch and GitCommitHash describe run, they are empty if they are unknown.
+	GitBranch     string
+	GitCommitHash string
+	// RunName is a short name of run, e.g. master@aabbccdd.
+	RunName string
+	// Directory is a remote directory of run.
+	Directory string
+	// Components are repositories of run with Name, Branch, Commit, ShortCommit and links BranchURL and CommitURL,
+	// links are empty if repo of component is unknown.
+	Components []ComponentTemplate
+	// ChartConfig are charts with series aligned with XAxis.
+	ChartConfig []ChartTemplate
+	XAxis       XAxis
+	// Warnings are data problems found while collecting charts.
+	Warnings []string
+	// Comparison is set for report comparing two runs.
+	Comparison *Comparison
+	// Verdict is set if report is checked with threshold rules.
+	Verdict *Verdict
+	// ChartLibrary is an inlined chart library script, ChartLibraryURL is used if it is empty.
+	ChartLibrary    template.JS
+	ChartLibraryURL string
+}
+
+func MakeReport(reader TemplateDataReader, wr io.Writer, opts Options) error {
 	c, err := reader.ReadTemplateData()
 	if err != nil {
 		return errors.Wrap(err, MakeReportErrorMessage)
 	}
 
-	templateData := struct {
-		GitBranch     string
-		GitCommitHash string
-		ChartConfig   string
-		XAxis         string
-	}{
+	if err := opts.Validate(); err != nil {
+		return errors.Wrap(err, MakeReportErrorMessage)
+	}
+	var library string
+	if !opts.CDN {
+		library, err = chartLibrary()
+		if err != nil {
+			return errors.Wrap(err, MakeReportErrorMessage)
+		}
+	}
+
+	templateData := HTMLTemplateData{
 		GitBranch:     c.GitBranch,
 		GitCommitHash: c.GitCommitHash,
-		ChartConfig:   mustMarshall(c.ChartConfig),
-		XAxis:         mustMarshall(c.xAxis),
+		RunName:       c.RunName(),
+		Directory:     c.Directory,
+		Components:    c.Components,
+		ChartConfig:   c.ChartConfig,
+		XAxis:         c.xAxis,
+		Warnings:      c.Warnings,
+		Comparison:    c.Comparison,
+		Verdict:       c.Verdict,
+		// library is trusted, closing script tags are escaped in it
+		ChartLibrary:    template.JS(library),
+		ChartLibraryURL: ChartLibraryURL,
 	}
 
-	f, err := pkger.Open("/pkg/report/template.html")
+	tmpl, err := reportTemplate(opts.TemplateDir)
 	if err != nil {
 		return errors.Wrap(err, MakeReportErrorMessage)
 	}
-	defer f.Close()
-
-	buf, err := ioutil.ReadAll(f)
+	err = tmpl.ExecuteTemplate(wr, ReportTemplateName, templateData)
 	if err != nil {
 		return errors.Wrap(err, MakeReportErrorMessage)
 	}
 
-	tmpl, err := template.New("report").Parse(string(buf))
-	if err != nil {
-		return errors.Wrap(err, MakeReportErrorMessage)
+	return nil
+}
+
+// reportTemplate parses html files of user template directory or bundled template.
+func reportTemplate(dir string) (*template.Template, error) {
+	if dir != "" {
+		tmpl, err := template.ParseGlob(filepath.Join(dir, "*.html"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse templates of %s", dir)
+		}
+		if tmpl.Lookup(ReportTemplateName) == nil {
+			return nil, errors.Errorf("template directory %s has no %s", dir, ReportTemplateName)
+		}
+		return tmpl, nil
 	}
-	err = tmpl.Execute(wr, templateData)
+
+	f, err := pkger.Open("/pkg/report/template.html")
 	if err != nil {
-		return errors.Wrap(err, MakeReportErrorMessage)
+		return nil, err
 	}
+	defer f.Close()
 
-	return nil
+	buf, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(ReportTemplateName).Parse(string(buf))
 }
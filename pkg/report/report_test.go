@@ -0,0 +1,51 @@
+package report
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeReport_Escaping(t *testing.T) {
+	data := &TemplateData{
+		GitBranch: `feature/"quotes"`,
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", Description: `it's </script><script>alert(1)</script>`, Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(1)}},
+			}},
+		},
+		Warnings: []string{"<b>bold</b>"},
+		xAxis:    XAxis{Name: "Nodes count", Data: []string{"5"}},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, MakeReport(staticReader{data}, buf, Options{CDN: true}))
+	out := buf.String()
+	require.NotContains(t, out, "<script>alert(1)")
+	require.Contains(t, out, `"description":"it's \u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"`)
+	require.Contains(t, out, `{"name":"Nodes count","data":["5"]}`)
+	require.Contains(t, out, "&lt;b&gt;bold&lt;/b&gt;")
+	require.Contains(t, out, `feature/&#34;quotes&#34;`)
+}
+
+func TestMakeReport_TemplateDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "templates")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	data := &TemplateData{GitBranch: "master", GitCommitHash: "aabbccddeeff"}
+
+	_, err = reportTemplate(dir)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "header.html"), []byte(`{{define "header"}}<h1>{{.RunName}}</h1>{{end}}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ReportTemplateName), []byte(`{{template "header" .}}charts: {{len .ChartConfig}}`), 0644))
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, MakeReport(staticReader{data}, buf, Options{CDN: true, TemplateDir: dir}))
+	require.Equal(t, "<h1>master@aabbccdd</h1>charts: 0", buf.String())
+}
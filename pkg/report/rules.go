@@ -0,0 +1,205 @@
+package report
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// RulesConfig sets file with threshold rules evaluated into verdict of report.
+type RulesConfig struct {
+	// File is a path to yaml rules file, rules are not checked if it is empty.
+	File string `mapstructure:"file"`
+}
+
+// Rule limits values of chart quantile. Limit is Max + PerNode * network size,
+// so PerNode is only allowed when x-axis is network size.
+type Rule struct {
+	Chart string `yaml:"chart"`
+	// Quantile is checked, all quantiles are checked if it is empty.
+	Quantile string `yaml:"quantile"`
+	// Series is a label of series property value, e.g. "latency 50ms", all series are checked if it is empty.
+	Series  string  `yaml:"series"`
+	Max     float64 `yaml:"max"`
+	PerNode float64 `yaml:"pernode"`
+}
+
+func (r Rule) limit(axis XAxis, x string) (float64, error) {
+	if r.PerNode == 0 {
+		return r.Max, nil
+	}
+	if axis.Property != DefaultXAxisProperty {
+		return 0, errors.Errorf("rule for chart %s sets pernode, but x-axis is not %s", r.Chart, DefaultXAxisProperty)
+	}
+	n, err := strconv.ParseFloat(x, 64)
+	if err != nil {
+		return 0, errors.Errorf("rule for chart %s needs numeric network size, got %q", r.Chart, x)
+	}
+	return r.Max + r.PerNode*n, nil
+}
+
+func (r Rule) matches(chart string, s SeriesTemplate) bool {
+	return r.Chart == chart &&
+		(r.Quantile == "" || r.Quantile == s.Name) &&
+		(r.Series == "" || r.Series == s.Group)
+}
+
+// Rules is a content of rules file.
+type Rules struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// ReadRules reads rules from yaml file.
+func ReadRules(filename string) (Rules, error) {
+	var rules Rules
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return rules, errors.Wrap(err, "failed to read rules file")
+	}
+	if err := yaml.UnmarshalStrict(buf, &rules); err != nil {
+		return rules, errors.Wrap(err, "failed to parse rules file")
+	}
+	for i, r := range rules.Rules {
+		if r.Chart == "" {
+			return rules, errors.Errorf("rule %d: chart is required", i+1)
+		}
+	}
+	return rules, nil
+}
+
+// LimitTemplate is a red flag line of rule drawn on chart, values are aligned with x-axis.
+// Html report draws it as a mark line of the first checked series, so it is not a series of its own.
+type LimitTemplate struct {
+	Name     string     `json:"name"`
+	Quantile string     `json:"quantile"`
+	Group    string     `json:"group,omitempty"`
+	Values   []*float64 `json:"values"`
+}
+
+// RuleCheck is a result of checking one value with rule.
+type RuleCheck struct {
+	Chart    string  `json:"chart"`
+	Unit     string  `json:"unit,omitempty"`
+	Quantile string  `json:"quantile"`
+	Group    string  `json:"series,omitempty"`
+	X        string  `json:"x"`
+	Value    float64 `json:"value"`
+	Limit    float64 `json:"limit"`
+	Passed   bool    `json:"passed"`
+}
+
+func (c RuleCheck) ValueText() string { return formatValue(&c.Value) }
+func (c RuleCheck) LimitText() string { return formatValue(&c.Limit) }
+
+// Verdict is a result of checking report with rules.
+type Verdict struct {
+	Checks []RuleCheck `json:"checks"`
+	// Errors are rules that can't be checked, e.g. with unknown chart.
+	Errors []string `json:"errors"`
+}
+
+// Passed returns true if all checks are passed and all rules are checked.
+func (v Verdict) Passed() bool {
+	return len(v.Failures()) == 0 && len(v.Errors) == 0
+}
+
+// Failures returns failed checks.
+func (v Verdict) Failures() []RuleCheck {
+	var failed []RuleCheck
+	for _, c := range v.Checks {
+		if !c.Passed {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// ApplyRules checks report series with rules and adds their limit lines to charts.
+// Baseline series of comparison report are not checked.
+func ApplyRules(data *TemplateData, rules Rules) {
+	verdict := &Verdict{}
+	for i, r := range rules.Rules {
+		name := fmt.Sprintf("limit %s%s", r.Chart, quantileLabel(r.Quantile))
+		if r.Series != "" {
+			name += ", " + r.Series
+		}
+
+		values := make([]*float64, len(data.xAxis.Data))
+		var limitErr error
+		for j, x := range data.xAxis.Data {
+			limit, err := r.limit(data.xAxis, x)
+			if err != nil {
+				limitErr = err
+				break
+			}
+			values[j] = &limit
+		}
+		if limitErr != nil {
+			verdict.Errors = append(verdict.Errors, fmt.Sprintf("rule %d: %v", i+1, limitErr))
+			continue
+		}
+
+		matched := false
+		for c := range data.ChartConfig {
+			chart := &data.ChartConfig[c]
+			var checked *SeriesTemplate
+			for k, s := range chart.Series {
+				if s.Run == SeriesRunBaseline || !r.matches(chartName(*chart), s) {
+					continue
+				}
+				if checked == nil {
+					checked = &chart.Series[k]
+				}
+				for j, v := range s.Data {
+					if v == nil || j >= len(values) {
+						continue
+					}
+					verdict.Checks = append(verdict.Checks, RuleCheck{
+						Chart:    chart.Name,
+						Unit:     chart.YAxisName,
+						Quantile: s.Name,
+						Group:    s.Group,
+						X:        data.xAxis.Data[j],
+						Value:    *v,
+						Limit:    *values[j],
+						Passed:   *v <= *values[j],
+					})
+				}
+			}
+			if checked != nil {
+				matched = true
+				chart.Limits = append(chart.Limits, LimitTemplate{Name: name, Quantile: checked.Name, Group: checked.Group, Values: values})
+			}
+		}
+		if !matched {
+			verdict.Errors = append(verdict.Errors, fmt.Sprintf("rule %d: no series of chart %s%s", i+1, r.Chart, quantileLabel(r.Quantile)))
+		}
+	}
+	data.Verdict = verdict
+}
+
+// chartName returns name of chart in data files, panels have series value in their name.
+func chartName(chart ChartTemplate) string {
+	if chart.Metric != "" {
+		return chart.Metric
+	}
+	return chart.Name
+}
+
+// RulesReader checks template data of reader with rules.
+type RulesReader struct {
+	Reader TemplateDataReader
+	Rules  Rules
+}
+
+func (r RulesReader) ReadTemplateData() (*TemplateData, error) {
+	data, err := r.Reader.ReadTemplateData()
+	if err != nil {
+		return nil, err
+	}
+	ApplyRules(data, r.Rules)
+	return data, nil
+}
@@ -0,0 +1,107 @@
+package report
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, "rules.yml")
+
+	require.NoError(t, ioutil.WriteFile(filename, []byte("rules:\n  - chart: phase2_duration\n    quantile: \"0.99\"\n    max: 10\n    pernode: 0.5\n"), 0644))
+	rules, err := ReadRules(filename)
+	require.NoError(t, err)
+	require.Equal(t, []Rule{{Chart: "phase2_duration", Quantile: "0.99", Max: 10, PerNode: 0.5}}, rules.Rules)
+
+	require.NoError(t, ioutil.WriteFile(filename, []byte("rules:\n  - quantile: \"0.99\"\n"), 0644))
+	_, err = ReadRules(filename)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filename, []byte("rules:\n  - chart: a\n    limit: 1\n"), 0644))
+	_, err = ReadRules(filename)
+	require.Error(t, err)
+}
+
+func TestApplyRules(t *testing.T) {
+	data := &TemplateData{
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", Series: []SeriesTemplate{
+				{Name: "0.5", Data: []*float64{float(5), float(9)}},
+				{Name: "0.99", Data: []*float64{float(10), nil}},
+				{Name: "0.99", Run: SeriesRunBaseline, Data: []*float64{float(100), float(100)}},
+			}},
+		},
+		xAxis: XAxis{Property: DefaultXAxisProperty, Data: []string{"5", "10"}},
+	}
+
+	ApplyRules(data, Rules{Rules: []Rule{
+		{Chart: "phase2_duration", Max: 5, PerNode: 0.5},
+		{Chart: "unknown", Max: 1},
+	}})
+
+	verdict := data.Verdict
+	require.NotNil(t, verdict)
+	require.False(t, verdict.Passed())
+	require.Len(t, verdict.Checks, 3)
+	require.Equal(t, []string{"rule 2: no series of chart unknown"}, verdict.Errors)
+
+	failures := verdict.Failures()
+	require.Len(t, failures, 1)
+	require.Equal(t, "0.99", failures[0].Quantile)
+	require.Equal(t, "10.00", failures[0].ValueText())
+	require.Equal(t, "7.50", failures[0].LimitText())
+
+	limits := data.ChartConfig[0].Limits
+	require.Len(t, limits, 1)
+	require.Equal(t, "limit phase2_duration", limits[0].Name)
+	require.Equal(t, "0.5", limits[0].Quantile)
+	require.Equal(t, []interface{}{7.5, 10.0}, seriesValues(SeriesTemplate{Data: limits[0].Values}))
+	// limits are not series, so exports have only measured values
+	require.Len(t, data.ChartConfig[0].Series, 3)
+	require.Len(t, DataPoints(data), 5)
+}
+
+func TestApplyRules_PerNodeAxis(t *testing.T) {
+	data := &TemplateData{
+		ChartConfig: []ChartTemplate{
+			{Name: "phase2_duration", Series: []SeriesTemplate{{Name: "0.5", Data: []*float64{float(5), float(9)}}}},
+		},
+		xAxis: XAxis{Property: "latency", Data: []string{"50", "100"}},
+	}
+
+	ApplyRules(data, Rules{Rules: []Rule{
+		{Chart: "phase2_duration", Max: 5, PerNode: 0.5},
+		{Chart: "phase2_duration", Max: 10},
+	}})
+
+	verdict := data.Verdict
+	require.Equal(t, []string{"rule 1: rule for chart phase2_duration sets pernode, but x-axis is not network_size"}, verdict.Errors)
+	require.Len(t, verdict.Checks, 2)
+	require.Len(t, data.ChartConfig[0].Limits, 1)
+}
+
+func TestRulesReader(t *testing.T) {
+	fs := memFS{}
+	loadTestData(t, fs, "run")
+
+	reader := RulesReader{
+		Reader: newTestClient(fs, "run"),
+		Rules:  Rules{Rules: []Rule{{Chart: "phase2_duration", Quantile: "0.99", Max: 1e9}}},
+	}
+	data, err := reader.ReadTemplateData()
+	require.NoError(t, err)
+	require.True(t, data.Verdict.Passed())
+	require.Len(t, data.Verdict.Checks, 4)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, MakeReport(reader, out, Options{CDN: true}))
+	require.Contains(t, out.String(), "Verdict: PASS, 0 of 4 checks failed")
+}
@@ -0,0 +1,68 @@
+package report
+
+const (
+	SeriesLayoutSeries = "series"
+	SeriesLayoutPanels = "panels"
+)
+
+// SeriesConfig sets range property which values are drawn separately, e.g. latency of network.
+type SeriesConfig struct {
+	// Property is a name of range property, values are not split if it is empty.
+	Property string `mapstructure:"property"`
+	// Layout is "series" to draw series for every value on the same chart
+	// or "panels" to draw separate chart for every value, default is "series".
+	Layout string `mapstructure:"layout"`
+}
+
+func (cfg SeriesConfig) panels() bool {
+	return cfg.Property != "" && cfg.Layout == SeriesLayoutPanels
+}
+
+func (cfg SeriesConfig) label(value string) string {
+	if cfg.Property == "" {
+		return ""
+	}
+	return cfg.Property + " " + value
+}
+
+// seriesGroup is a set of files with the same series property value aligned with x-axis.
+type seriesGroup struct {
+	value string
+	files []*dataFile
+}
+
+// groupDataFiles splits sorted files by series property value and aligns every group with x-axis values.
+// Groups are sorted like x-axis values. Files with the same values as previous ones are skipped with warning.
+func groupDataFiles(files []dataFile, xValues []string, xValue func(dataFile) string, warns *warnings) []seriesGroup {
+	xIndex := make(map[string]int, len(xValues))
+	for i, x := range xValues {
+		xIndex[x] = i
+	}
+
+	groupFiles := make([]dataFile, 0)
+	groupIndex := make(map[string]int)
+	for _, f := range files {
+		if _, ok := groupIndex[f.group.raw]; !ok {
+			groupIndex[f.group.raw] = len(groupFiles)
+			groupFiles = append(groupFiles, dataFile{x: f.group})
+		}
+	}
+	sortDataFiles(groupFiles)
+
+	groups := make([]seriesGroup, 0, len(groupFiles))
+	for i, g := range groupFiles {
+		groupIndex[g.x.raw] = i
+		groups = append(groups, seriesGroup{value: g.x.raw, files: make([]*dataFile, len(xValues))})
+	}
+
+	for i := range files {
+		group := groups[groupIndex[files[i].group.raw]]
+		x := xIndex[xValue(files[i])]
+		if prev := group.files[x]; prev != nil {
+			warns.add("file %s has the same properties as %s and is skipped", files[i].filename, prev.filename)
+			continue
+		}
+		group.files[x] = &files[i]
+	}
+	return groups
+}
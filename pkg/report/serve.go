@@ -0,0 +1,86 @@
+package report
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ServeConfig sets report server.
+type ServeConfig struct {
+	// Root is a directory with runs to browse, only configured run is served if it is empty.
+	Root string `mapstructure:"root"`
+	// CacheTTL is time for which report data is cached, default is 1m.
+	CacheTTL time.Duration `mapstructure:"cachettl"`
+	// CacheSize is maximal number of cached reports, default is 256.
+	CacheSize int `mapstructure:"cachesize"`
+	// ShutdownTimeout is time to finish active requests on shutdown, default is 10s.
+	ShutdownTimeout time.Duration `mapstructure:"shutdowntimeout"`
+
+	TLS  ServeTLSConfig  `mapstructure:"tls"`
+	Auth ServeAuthConfig `mapstructure:"auth"`
+}
+
+// ServeTLSConfig sets certificate of https server, plain http is served if it is empty.
+type ServeTLSConfig struct {
+	CertFile string `mapstructure:"certfile"`
+	KeyFile  string `mapstructure:"keyfile"`
+}
+
+// Enabled returns true if certificate is set.
+func (cfg ServeTLSConfig) Enabled() bool {
+	return cfg.CertFile != "" || cfg.KeyFile != ""
+}
+
+// ServeAuthConfig sets basic auth and bearer token of server, requests are allowed with any of them.
+// Secrets can be read from files, e.g. mounted kubernetes secrets. Server is open if nothing is set.
+type ServeAuthConfig struct {
+	Username     string `mapstructure:"username"`
+	Password     string `mapstructure:"password" insconfigsecret:""`
+	PasswordFile string `mapstructure:"passwordfile"`
+	Token        string `mapstructure:"token" insconfigsecret:""`
+	TokenFile    string `mapstructure:"tokenfile"`
+}
+
+// LoadSecrets checks config and returns it with password and token read from files.
+func (cfg ServeConfig) LoadSecrets() (ServeConfig, error) {
+	if cfg.TLS.Enabled() && (cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "") {
+		return cfg, errors.New("both tls certfile and keyfile are required")
+	}
+
+	var err error
+	auth := &cfg.Auth
+	if auth.Password, err = readSecret("password", auth.Password, auth.PasswordFile); err != nil {
+		return cfg, err
+	}
+	if auth.Token, err = readSecret("token", auth.Token, auth.TokenFile); err != nil {
+		return cfg, err
+	}
+	auth.PasswordFile, auth.TokenFile = "", ""
+
+	if (auth.Username == "") != (auth.Password == "") {
+		return cfg, errors.New("both auth username and password are required for basic auth")
+	}
+	return cfg, nil
+}
+
+// readSecret returns value or trimmed content of file.
+func readSecret(name, value, filename string) (string, error) {
+	if filename == "" {
+		return value, nil
+	}
+	if value != "" {
+		return "", errors.Errorf("auth %s and %sfile are both set", name, name)
+	}
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read auth %s file", name)
+	}
+	secret := strings.TrimSpace(string(buf))
+	if secret == "" {
+		return "", errors.Errorf("auth %s file %s is empty", name, filename)
+	}
+	return secret, nil
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/insolar/insconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeConfig_LoadSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	passwordFile := path.Join(dir, "password")
+	require.NoError(t, ioutil.WriteFile(passwordFile, []byte("s3cret\n"), 0600))
+	tokenFile := path.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("t0ken"), 0600))
+
+	cfg := ServeConfig{Auth: ServeAuthConfig{Username: "admin", PasswordFile: passwordFile, TokenFile: tokenFile}}
+	loaded, err := cfg.LoadSecrets()
+	require.NoError(t, err)
+	require.Equal(t, ServeAuthConfig{Username: "admin", Password: "s3cret", Token: "t0ken"}, loaded.Auth)
+
+	_, err = ServeConfig{Auth: ServeAuthConfig{Username: "admin", Password: "a", PasswordFile: passwordFile}}.LoadSecrets()
+	require.Error(t, err)
+
+	_, err = ServeConfig{Auth: ServeAuthConfig{Username: "admin"}}.LoadSecrets()
+	require.Error(t, err)
+
+	_, err = ServeConfig{Auth: ServeAuthConfig{TokenFile: path.Join(dir, "missing")}}.LoadSecrets()
+	require.Error(t, err)
+
+	_, err = ServeConfig{TLS: ServeTLSConfig{CertFile: "cert.pem"}}.LoadSecrets()
+	require.Error(t, err)
+}
+
+func TestServeConfig_Dump(t *testing.T) {
+	cfg := Config{}
+	cfg.Serve.Auth = ServeAuthConfig{Username: "admin", Password: "s3cret", Token: "t0ken"}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, insconfig.NewYamlDumper(cfg).DumpTo(buf))
+	require.Contains(t, buf.String(), "admin")
+	require.NotContains(t, buf.String(), "s3cret")
+	require.NotContains(t, buf.String(), "t0ken")
+}
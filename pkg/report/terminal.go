@@ -0,0 +1,251 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const MakeTerminalErrorMessage = "Failed to make terminal report"
+
+const (
+	DefaultTerminalWidth  = 100
+	DefaultTerminalHeight = 15
+
+	minTerminalWidth  = 40
+	minTerminalHeight = 5
+)
+
+// seriesMarkers are plot glyphs of series in order, limits are drawn with limitMarker.
+var seriesMarkers = []rune{'●', '○', '■', '□', '◆', '◇', '▲', '△', '×', '+', '*'}
+
+const limitMarker = '┈'
+
+// TerminalOptions sets size of terminal plots in characters.
+type TerminalOptions struct {
+	Width  int
+	Height int
+}
+
+func (opts TerminalOptions) size() (int, int) {
+	width, height := opts.Width, opts.Height
+	if width == 0 {
+		width = DefaultTerminalWidth
+	}
+	if height == 0 {
+		height = DefaultTerminalHeight
+	}
+	if width < minTerminalWidth {
+		width = minTerminalWidth
+	}
+	if height < minTerminalHeight {
+		height = minTerminalHeight
+	}
+	return width, height
+}
+
+// MakeTerminal writes every chart as unicode plot with legend and table of values.
+func MakeTerminal(reader TemplateDataReader, wr io.Writer, opts TerminalOptions) error {
+	c, err := reader.ReadTemplateData()
+	if err != nil {
+		return errors.Wrap(err, MakeTerminalErrorMessage)
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "Consensus performance report for %s\n", c.RunName())
+	if cmp := c.Comparison; cmp != nil {
+		fmt.Fprintf(buf, "Compared with baseline %s, tolerance %g%%: %d regressions\n", cmp.Baseline, cmp.Tolerance, cmp.Regressions())
+	}
+	if v := c.Verdict; v != nil {
+		status := "PASS"
+		if !v.Passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(buf, "Thresholds: %s, %d of %d checks failed\n", status, len(v.Failures()), len(v.Checks))
+	}
+	for _, w := range c.Warnings {
+		fmt.Fprintf(buf, "warning: %s\n", w)
+	}
+
+	width, height := opts.size()
+	for _, ct := range c.ChartConfig {
+		buf.WriteString("\n")
+		writeTerminalChart(buf, ct, c.xAxis, width, height)
+		buf.WriteString("\n")
+		if err := writeTerminalTable(buf, ct, c.xAxis); err != nil {
+			return errors.Wrap(err, MakeTerminalErrorMessage)
+		}
+	}
+
+	if _, err := wr.Write(buf.Bytes()); err != nil {
+		return errors.Wrap(err, MakeTerminalErrorMessage)
+	}
+	return nil
+}
+
+func writeTerminalChart(buf *bytes.Buffer, chart ChartTemplate, xAxis XAxis, width, height int) {
+	title := chart.Description
+	if title == "" {
+		title = chart.Name
+	}
+	if chart.YAxisName != "" {
+		title += ", " + chart.YAxisName
+	}
+	buf.WriteString(title + "\n")
+
+	lines := chartLines(chart)
+	min, max, step := valueRange(lines)
+
+	labels := make([]string, height)
+	labelWidth := 0
+	for r := range labels {
+		// label rows close to ticks only
+		v := max - float64(r)*(max-min)/float64(height-1)
+		tick := math.Round(v/step) * step
+		if math.Abs(v-tick) <= (max-min)/float64(height-1)/2 {
+			labels[r] = formatTick(tick)
+		}
+		if len(labels[r]) > labelWidth {
+			labelWidth = len(labels[r])
+		}
+	}
+
+	plotWidth := width - labelWidth - 2
+	n := len(xAxis.Data)
+	column := func(i int) int {
+		if n <= 1 {
+			return plotWidth / 2
+		}
+		return int(math.Round(float64(i) * float64(plotWidth-1) / float64(n-1)))
+	}
+	row := func(v float64) int {
+		return int(math.Round((max - v) / (max - min) * float64(height-1)))
+	}
+
+	grid := make([][]rune, height)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", plotWidth))
+	}
+
+	// limits are drawn first, so series are visible over them
+	order := make([]int, 0, len(lines))
+	for i, l := range lines {
+		if !l.marks {
+			order = append(order, i)
+		}
+	}
+	for i, l := range lines {
+		if l.marks {
+			order = append(order, i)
+		}
+	}
+
+	markers := make([]rune, len(lines))
+	series := 0
+	for i, l := range lines {
+		markers[i] = limitMarker
+		if l.marks {
+			markers[i] = seriesMarkers[series%len(seriesMarkers)]
+			series++
+		}
+	}
+
+	for _, li := range order {
+		l := lines[li]
+		for i := 0; i < n && i < len(l.values); i++ {
+			if l.values[i] == nil {
+				continue
+			}
+			if i+1 < n && i+1 < len(l.values) && l.values[i+1] != nil {
+				from, to := column(i), column(i+1)
+				prev := row(*l.values[i])
+				for col := from; col <= to; col++ {
+					t := float64(col-from) / math.Max(float64(to-from), 1)
+					r := row(*l.values[i] + (*l.values[i+1]-*l.values[i])*t)
+					// steep segments are filled vertically, so they have no gaps
+					for fill := r; fill != prev; {
+						grid[fill][col] = markers[li]
+						if fill < prev {
+							fill++
+						} else {
+							fill--
+						}
+					}
+					grid[r][col] = markers[li]
+					prev = r
+				}
+			}
+			grid[row(*l.values[i])][column(i)] = markers[li]
+		}
+	}
+
+	for r, line := range grid {
+		axis := "│"
+		if labels[r] != "" {
+			axis = "┤"
+		}
+		fmt.Fprintf(buf, "%*s %s%s\n", labelWidth, labels[r], axis, strings.TrimRight(string(line), " "))
+	}
+	fmt.Fprintf(buf, "%*s └%s\n", labelWidth, "", strings.Repeat("─", plotWidth))
+
+	xLabels := []rune(strings.Repeat(" ", plotWidth))
+	next := 0
+	for i, value := range xAxis.Data {
+		label := []rune(value)
+		start := column(i) - len(label)/2
+		if start < next {
+			continue
+		}
+		if start+len(label) > plotWidth {
+			start = plotWidth - len(label)
+		}
+		if start < 0 || start < next {
+			continue
+		}
+		copy(xLabels[start:], label)
+		next = start + len(label) + 1
+	}
+	prefix := strings.Repeat(" ", labelWidth+2)
+	buf.WriteString(prefix + strings.TrimRight(string(xLabels), " ") + "\n")
+	if xAxis.Name != "" {
+		pad := (plotWidth - utf8.RuneCountInString(xAxis.Name)) / 2
+		if pad < 0 {
+			pad = 0
+		}
+		buf.WriteString(prefix + strings.Repeat(" ", pad) + xAxis.Name + "\n")
+	}
+
+	legend := make([]string, 0, len(lines))
+	for i, l := range lines {
+		legend = append(legend, string(markers[i])+" "+l.label)
+	}
+	buf.WriteString(prefix + strings.Join(legend, "  ") + "\n")
+}
+
+func writeTerminalTable(buf *bytes.Buffer, chart ChartTemplate, xAxis XAxis) error {
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := append([]string{"series"}, xAxis.Data...)
+	if _, err := fmt.Fprintln(tw, strings.Join(header, "\t")+"\t"); err != nil {
+		return err
+	}
+	for _, s := range chart.Series {
+		cells := []string{seriesLabel(s)}
+		for i := range xAxis.Data {
+			var v *float64
+			if i < len(s.Data) {
+				v = s.Data[i]
+			}
+			cells = append(cells, formatValue(v))
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(cells, "\t")+"\t"); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
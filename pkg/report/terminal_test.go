@@ -0,0 +1,36 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeTerminal(t *testing.T) {
+	chart, xAxis := imageTestChart()
+	data := &TemplateData{GitBranch: "master", ChartConfig: []ChartTemplate{chart}, xAxis: xAxis}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, MakeTerminal(staticReader{data}, out, TerminalOptions{Width: 40, Height: 5}))
+	require.Equal(t, strings.Join([]string{
+		"Consensus performance report for master",
+		"",
+		"Duration of consensus phase2, ms",
+		"40 ┤┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈┈",
+		"30 ┤                                   ●",
+		"20 ┤       ○○○○○○○○○○○○○○○○○○○○○○○○○○○○○",
+		"10 ┤○○○○○○○",
+		" 0 ┤",
+		"   └────────────────────────────────────",
+		"    5                10               15",
+		"                Nodes count",
+		"    ● 0.5 quantile  ○ 0.5 quantile, baseline  ┈ limit phase2_duration",
+		"",
+		"                  series      5     10     15",
+		"            0.5 quantile  10.00      -  30.00",
+		"  0.5 quantile, baseline  12.00  20.00  25.00",
+		"",
+	}, "\n"), out.String())
+}
@@ -0,0 +1,236 @@
+package report
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/middleware"
+	"github.com/insolar/consensus-reports/pkg/storage"
+)
+
+const TrendXAxisName = "Commit"
+
+// TrendConfig sets runs and metrics of trend report.
+type TrendConfig struct {
+	// Root is a directory with runs, they can be nested in it.
+	Root string `mapstructure:"root"`
+	// Branch filters runs by branch from run metadata, all runs are used if it is empty.
+	Branch string `mapstructure:"branch"`
+	// XValue is a fixed x-axis value of runs to plot, e.g. network size "17".
+	XValue string `mapstructure:"xvalue"`
+	// Charts to plot, all charts are plotted if it is empty.
+	Charts []string `mapstructure:"charts"`
+	// Quantiles to plot, all quantiles are plotted if it is empty.
+	Quantiles []string `mapstructure:"quantiles"`
+	// Limit is a count of last runs to plot, all runs are plotted if it is zero.
+	Limit int `mapstructure:"limit"`
+}
+
+// TrendCommandConfig is a config of trend command, it has only keys used by trend report.
+type TrendCommandConfig struct {
+	Webdav   middleware.WebDavConfig
+	XAxis    XAxisConfig    `mapstructure:"xaxis"`
+	Series   SeriesConfig   `mapstructure:"series"`
+	Trend    TrendConfig    `mapstructure:"trend"`
+	Template TemplateConfig `mapstructure:"template"`
+}
+
+// ReportConfig returns report config of webdav client reading runs of trend.
+func (cfg TrendCommandConfig) ReportConfig() Config {
+	return Config{
+		Webdav:   cfg.Webdav,
+		XAxis:    cfg.XAxis,
+		Series:   cfg.Series,
+		Trend:    cfg.Trend,
+		Template: cfg.Template,
+	}
+}
+
+func (cfg TrendConfig) validate() error {
+	if cfg.Root == "" {
+		return errors.New("trend root is required")
+	}
+	if cfg.XValue == "" {
+		return errors.New("trend xvalue is required")
+	}
+	return nil
+}
+
+// reportPath returns path of trend report in root directory, e.g. /consensus/trend-master.html.
+func (cfg TrendConfig) reportPath() string {
+	name := "trend"
+	if cfg.Branch != "" {
+		name += "-" + strings.Replace(cfg.Branch, "/", "-", -1)
+	}
+	return path.Join("/", cfg.Root, name+".html")
+}
+
+// TrendReader reads many runs and plots their values at fixed x-axis value, ordered by commit time.
+type TrendReader struct {
+	client *WebdavClient
+}
+
+// TrendReader returns reader of trend report for runs under cfg.Trend.Root.
+func (w *WebdavClient) TrendReader() TrendReader {
+	return TrendReader{client: w}
+}
+
+// WriteReport saves trend report to root directory and returns its path.
+func (r TrendReader) WriteReport(data []byte) (string, error) {
+	filePath := r.client.cfg.Trend.reportPath()
+	if err := r.client.fs.Write(filePath, data, 0644); err != nil {
+		return "", errors.Wrap(err, "failed to write trend report")
+	}
+	return filePath, nil
+}
+
+type trendKey struct {
+	chart    string
+	quantile string
+	group    string
+}
+
+func (r TrendReader) ReadTemplateData() (*TemplateData, error) {
+	cfg := r.client.cfg.Trend
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	runs, err := storage.ListRuns(r.client.fs, cfg.Root)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list runs")
+	}
+	runs = trendRuns(runs, cfg)
+
+	result := &TemplateData{ChartConfig: []ChartTemplate{}, GitBranch: cfg.Branch, Directory: cfg.Root}
+	result.xAxis = XAxis{Name: TrendXAxisName, Data: []string{}}
+	warns := &warnings{}
+	if len(runs) == 0 {
+		warns.add("no runs of branch %q under %s", cfg.Branch, cfg.Root)
+	}
+
+	charts := make([]ChartTemplate, 0)
+	chartIndex := make(map[string]int)
+	series := make(map[trendKey][]*float64)
+	seriesOrder := make(map[string][]trendKey)
+
+	chartSet := stringSet(cfg.Charts)
+	quantileSet := stringSet(cfg.Quantiles)
+
+	for _, run := range runs {
+		data, err := r.client.ForDirectory("/" + run.Dir).ReadTemplateData()
+		if err != nil {
+			warns.add("run %s is skipped: %v", run.Dir, err)
+			continue
+		}
+		x, ok := findXValue(data.xAxis.Data, cfg.XValue)
+		if !ok {
+			warns.add("run %s has no %s value %s", run.Dir, data.xAxis.Name, cfg.XValue)
+			continue
+		}
+
+		point := len(result.xAxis.Data)
+		result.xAxis.Data = append(result.xAxis.Data, trendLabel(run))
+		result.GitCommitHash = run.Index.RunInfo().Hash
+
+		for _, ct := range data.ChartConfig {
+			if len(chartSet) > 0 && !chartSet[ct.Name] {
+				continue
+			}
+			if _, ok := chartIndex[ct.Name]; !ok {
+				chartIndex[ct.Name] = len(charts)
+				charts = append(charts, ChartTemplate{Name: ct.Name, Description: ct.Description, YAxisName: ct.YAxisName})
+			}
+			for _, s := range ct.Series {
+				if len(quantileSet) > 0 && !quantileSet[s.Name] {
+					continue
+				}
+				key := trendKey{chart: ct.Name, quantile: s.Name, group: s.Group}
+				if _, ok := series[key]; !ok {
+					seriesOrder[ct.Name] = append(seriesOrder[ct.Name], key)
+				}
+				values := series[key]
+				for len(values) <= point {
+					values = append(values, nil)
+				}
+				values[point] = s.Data[x]
+				series[key] = values
+			}
+		}
+	}
+
+	for _, ct := range charts {
+		for _, key := range seriesOrder[ct.Name] {
+			values := series[key]
+			for len(values) < len(result.xAxis.Data) {
+				values = append(values, nil)
+			}
+			ct.Series = append(ct.Series, SeriesTemplate{Name: key.quantile, Group: key.group, Data: values})
+		}
+		result.ChartConfig = append(result.ChartConfig, ct)
+	}
+	for _, name := range cfg.Charts {
+		if _, ok := chartIndex[name]; !ok {
+			warns.add("chart %s is not found in runs", name)
+		}
+	}
+
+	result.Warnings = warns.list
+	return result, nil
+}
+
+// trendRuns returns runs of branch ordered by commit time, oldest first.
+func trendRuns(runs []storage.Run, cfg TrendConfig) []storage.Run {
+	result := make([]storage.Run, 0, len(runs))
+	for _, run := range runs {
+		if cfg.Branch == "" || run.Index.RunInfo().Branch == cfg.Branch {
+			result = append(result, run)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CommitDate().Before(result[j].CommitDate())
+	})
+	if cfg.Limit > 0 && len(result) > cfg.Limit {
+		result = result[len(result)-cfg.Limit:]
+	}
+	return result
+}
+
+// trendLabel returns x-axis label of run with short hash and commit date.
+func trendLabel(run storage.Run) string {
+	hash := run.Index.RunInfo().Hash
+	if len(hash) > 8 {
+		hash = hash[:8]
+	}
+	if hash == "" {
+		hash = run.Dir
+	}
+	return hash + " " + run.CommitDate().Format("2006-01-02")
+}
+
+// findXValue returns index of x-axis value, numeric values are compared without unit.
+func findXValue(values []string, value string) (int, bool) {
+	number := value
+	if parsed, ok := parseAxisValue(value); ok {
+		number = strconv.FormatFloat(parsed.number, 'f', -1, 64)
+	}
+	for i, v := range values {
+		if v == value || v == number {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func stringSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
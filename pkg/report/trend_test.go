@@ -0,0 +1,107 @@
+package report
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/insolar/insconfig"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+// writeRun writes run with network sizes 5 and 17, phase2 duration at 17 nodes is value.
+func writeRun(t *testing.T, fs memFS, dir string, run replicator.RunMetadata, value float64) {
+	index := replicator.OutputConfig{
+		Charts:    []string{"phase2_duration"},
+		Quantiles: []string{"0.5"},
+		Files:     []string{"network_size_5.json", "network_size_17.json"},
+		Run:       &run,
+	}
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+	fs[path.Join("/", dir, replicator.DefaultConfigFilename)] = data
+
+	for size, v := range map[string]float64{"5": 1, "17": value} {
+		f := metricreplicator.ResultData{
+			Records: []metricreplicator.RecordInfo{
+				{Chart: "phase2_duration", Unit: "ms", Quantile: "0.5", Value: v},
+				{Chart: "phase3_duration", Unit: "ms", Quantile: "0.5", Value: v},
+			},
+			Properties: []metricreplicator.NetworkProperty{{Name: "network_size", Value: size}},
+		}
+		data, err := json.Marshal(f)
+		require.NoError(t, err)
+		fs[path.Join("/", dir, "network_size_"+size+".json")] = data
+	}
+}
+
+func TestTrendReader(t *testing.T) {
+	day := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	fs := memFS{}
+	// replicated in reverse order of commits
+	writeRun(t, fs, "consensus/master/2020-07-03/aaaaaaaaaa", replicator.RunMetadata{
+		Branch: "master", Hash: "aaaaaaaaaa", Date: day.Add(72 * time.Hour), CommitDate: day,
+	}, 100)
+	writeRun(t, fs, "consensus/master/2020-07-02/bbbbbbbbbb", replicator.RunMetadata{
+		Branch: "master", Hash: "bbbbbbbbbb", Date: day.Add(48 * time.Hour), CommitDate: day.Add(24 * time.Hour),
+	}, 200)
+	writeRun(t, fs, "consensus/master/2020-07-01/cccccccccc", replicator.RunMetadata{
+		Branch: "master", Hash: "cccccccccc", Date: day.Add(24 * time.Hour), CommitDate: day.Add(48 * time.Hour),
+	}, 300)
+	writeRun(t, fs, "consensus/feature/2020-07-01/dddddddddd", replicator.RunMetadata{
+		Branch: "feature", Hash: "dddddddddd", Date: day, CommitDate: day,
+	}, 1000)
+
+	client := newTestClient(fs, "")
+	client.cfg.Trend = TrendConfig{
+		Root:   "consensus",
+		Branch: "master",
+		XValue: "17",
+		Charts: []string{"phase2_duration", "unknown"},
+	}
+
+	data, err := client.TrendReader().ReadTemplateData()
+	require.NoError(t, err)
+	require.Equal(t, TrendXAxisName, data.xAxis.Name)
+	require.Equal(t, []string{"aaaaaaaa 2020-07-01", "bbbbbbbb 2020-07-02", "cccccccc 2020-07-03"}, data.xAxis.Data)
+	require.Equal(t, "cccccccccc", data.GitCommitHash)
+	require.Len(t, data.ChartConfig, 1)
+	require.Equal(t, []interface{}{100.0, 200.0, 300.0}, seriesValues(data.ChartConfig[0].Series[0]))
+	require.Equal(t, []string{"chart unknown is not found in runs"}, data.Warnings)
+
+	client.cfg.Trend.Limit = 2
+	client.cfg.Trend.XValue = "5"
+	data, err = client.TrendReader().ReadTemplateData()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{1.0, 1.0}, seriesValues(data.ChartConfig[0].Series[0]))
+
+	filePath, err := client.TrendReader().WriteReport([]byte("trend"))
+	require.NoError(t, err)
+	require.Equal(t, "/consensus/trend-master.html", filePath)
+	require.Equal(t, []byte("trend"), fs[filePath])
+}
+
+type trendConfigPath string
+
+func (p trendConfigPath) GetConfigPath() string { return string(p) }
+
+func TestTrendCommandConfig(t *testing.T) {
+	cfg := TrendCommandConfig{}
+	configurator := insconfig.New(insconfig.Params{
+		EnvPrefix:        "report",
+		FileNotRequired:  true,
+		ConfigPathGetter: trendConfigPath("../../cmd/trend/config.yml"),
+	})
+	err := configurator.Load(&cfg)
+	require.NoError(t, err)
+	require.Equal(t, "17", cfg.Trend.XValue)
+
+	reportCfg := cfg.ReportConfig()
+	require.Equal(t, cfg.Trend, reportCfg.Trend)
+	require.Equal(t, cfg.Webdav, reportCfg.Webdav)
+	require.Equal(t, DefaultXAxisProperty, reportCfg.XAxis.property())
+}
@@ -2,6 +2,7 @@ package report
 
 import (
 	"encoding/json"
+	"log"
 	"os"
 	"path"
 	"sort"
@@ -9,15 +10,14 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
-	"github.com/studio-b12/gowebdav"
 
 	"github.com/insolar/consensus-reports/pkg/metricreplicator"
 	"github.com/insolar/consensus-reports/pkg/middleware"
 	"github.com/insolar/consensus-reports/pkg/replicator"
+	"github.com/insolar/consensus-reports/pkg/storage"
 )
 
 const DefaultReportFileName = "index.html"
-const NetworkSizePrefix = "network_size_"
 const JSONFileExtension = ".json"
 const ReadTemplateDataErrorMessage = "Failed to read template data"
 
@@ -27,12 +27,17 @@ type MetricFileJSON metricreplicator.ResultData
 type ConfigFileJSON struct {
 	ChartNames []string `json:"charts"`
 	Quantiles  []string `json:"quantiles"` // series
+	Files      []string `json:"files"`
+	// Run is set by replicator, it is used when git config is empty.
+	Run *replicator.RunMetadata `json:"run"`
 }
 
 type filesystem interface {
+	storage.Filesystem
 	ReadDir(path string) ([]os.FileInfo, error)
 	Read(path string) ([]byte, error)
 	Write(path string, data []byte, _ os.FileMode) error
+	Remove(path string) error
 }
 
 type Config struct {
@@ -41,6 +46,16 @@ type Config struct {
 		Branch string
 		Hash   string
 	}
+	XAxis    XAxisConfig    `mapstructure:"xaxis"`
+	Series   SeriesConfig   `mapstructure:"series"`
+	Compare  CompareConfig  `mapstructure:"compare"`
+	Rules    RulesConfig    `mapstructure:"rules"`
+	Trend    TrendConfig    `mapstructure:"trend"`
+	Serve    ServeConfig    `mapstructure:"serve"`
+	Live     LiveConfig     `mapstructure:"live"`
+	Template TemplateConfig `mapstructure:"template"`
+	// Components are repositories of benchmarked build shown in report header.
+	Components []middleware.ComponentConfig `mapstructure:"components"`
 }
 
 type WebdavClient struct {
@@ -49,16 +64,36 @@ type WebdavClient struct {
 }
 
 func CreateWebdavClient(cfg Config) *WebdavClient {
-	client := gowebdav.NewClient(cfg.Webdav.Host, cfg.Webdav.Username, cfg.Webdav.Password)
-	client.SetTimeout(cfg.Webdav.Timeout)
-
+	client := storage.NewWebdavClient(cfg.Webdav.Host, cfg.Webdav.Username, cfg.Webdav.Password, cfg.Webdav.Timeout)
 	return &WebdavClient{cfg, client}
 }
 
-type fileInfo struct {
-	filename             string
-	networkPropertyValue int
-	networkPropertyUnit  string // just a thought for future properties like latency_50ms
+// ForDirectory returns client reading another run directory, git info is taken from its index.
+func (w *WebdavClient) ForDirectory(dir string) *WebdavClient {
+	cfg := w.cfg
+	cfg.Webdav.Directory = dir
+	cfg.Git.Branch = ""
+	cfg.Git.Hash = ""
+	return &WebdavClient{cfg, w.fs}
+}
+
+// CompareReader returns reader of comparison report if baseline is set in config.
+func (w *WebdavClient) CompareReader() (TemplateDataReader, bool) {
+	if w.cfg.Compare.Baseline == "" {
+		return w, false
+	}
+	return CompareReader{
+		Baseline:  w.ForDirectory(w.cfg.Compare.Baseline),
+		Candidate: w,
+		Tolerance: w.cfg.Compare.Tolerance,
+	}, true
+}
+
+type dataFile struct {
+	filename string
+	data     MetricFileJSON
+	x        axisValue
+	group    axisValue
 }
 
 func (w *WebdavClient) ReadTemplateData() (*TemplateData, error) {
@@ -67,12 +102,17 @@ func (w *WebdavClient) ReadTemplateData() (*TemplateData, error) {
 		return nil, errors.Wrap(err, ReadTemplateDataErrorMessage)
 	}
 
-	filenames, err := w.scanWebdavFiles()
+	filenames, err := w.scanWebdavFiles(reportCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, ReadTemplateDataErrorMessage)
+	}
+
+	files, err := w.readDataFiles(filenames)
 	if err != nil {
 		return nil, errors.Wrap(err, ReadTemplateDataErrorMessage)
 	}
 
-	return w.collectTemplateData(filenames, reportCfg)
+	return w.collectTemplateData(files, reportCfg)
 }
 
 func (w *WebdavClient) readConfigJSON() (*ConfigFileJSON, error) {
@@ -90,46 +130,42 @@ func (w *WebdavClient) readConfigJSON() (*ConfigFileJSON, error) {
 	return &reportCfg, nil
 }
 
-func (w *WebdavClient) scanWebdavFiles() ([]fileInfo, error) {
+// scanWebdavFiles returns data files from index or all json files of directory for indexes without files list.
+func (w *WebdavClient) scanWebdavFiles(reportCfg *ConfigFileJSON) ([]string, error) {
+	if len(reportCfg.Files) > 0 {
+		return reportCfg.Files, nil
+	}
+
 	files, err := w.fs.ReadDir(w.cfg.Webdav.Directory)
 	if err != nil {
 		return nil, errors.Wrap(err, ReadTemplateDataErrorMessage)
 	}
 
-	parseNumber := func(filename string) int {
-		trimmed := strings.TrimPrefix(filename, NetworkSizePrefix)
-		numStr := strings.TrimSuffix(trimmed, JSONFileExtension)
-
-		res, err := strconv.Atoi(numStr)
-		if err != nil {
-			panic(err)
-		}
-		return res
-	}
-
-	filenames := make([]fileInfo, 0)
+	filenames := make([]string, 0)
 	for _, file := range files {
-		if strings.HasPrefix(file.Name(), NetworkSizePrefix) && strings.HasSuffix(file.Name(), JSONFileExtension) {
-			filenames = append(filenames, fileInfo{file.Name(), parseNumber(file.Name()), "network_size"})
+		if file.IsDir() || isReportFile(file.Name()) {
+			continue
+		}
+		if strings.HasSuffix(file.Name(), JSONFileExtension) {
+			filenames = append(filenames, file.Name())
 		}
 	}
+	sort.Strings(filenames)
 
 	return filenames, nil
 }
 
-func (w *WebdavClient) collectTemplateData(filenames []fileInfo, reportCfg *ConfigFileJSON) (*TemplateData, error) {
-	sort.Slice(filenames, func(i, j int) bool {
-		return filenames[i].networkPropertyValue < filenames[j].networkPropertyValue
-	})
-
-	xValues := make([]int, 0)
-	for _, n := range filenames {
-		xValues = append(xValues, n.networkPropertyValue)
-	}
+// isReportFile checks for json files written next to data files, they are not data files.
+func isReportFile(name string) bool {
+	return name == replicator.DefaultConfigFilename || name == DefaultVerdictFileName || name == ReportFileName(FormatJSON)
+}
 
-	filesData := make([]MetricFileJSON, 0, len(filenames))
-	for _, file := range filenames {
-		buf, err := w.fs.Read(path.Join(w.cfg.Webdav.Directory, file.filename))
+// readDataFiles reads data files and takes x-axis value from their properties.
+// Files without x-axis property are skipped.
+func (w *WebdavClient) readDataFiles(filenames []string) ([]dataFile, error) {
+	files := make([]dataFile, 0, len(filenames))
+	for _, filename := range filenames {
+		buf, err := w.fs.Read(path.Join(w.cfg.Webdav.Directory, filename))
 		if err != nil {
 			return nil, errors.Wrap(err, ReadTemplateDataErrorMessage)
 		}
@@ -137,53 +173,170 @@ func (w *WebdavClient) collectTemplateData(filenames []fileInfo, reportCfg *Conf
 		var f MetricFileJSON
 		err = json.Unmarshal(buf, &f)
 		if err != nil {
-			return nil, errors.Wrap(err, ReadTemplateDataErrorMessage)
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", filename)
+		}
+
+		file, err := w.parseDataFile(filename, f)
+		if err != nil {
+			log.Printf("skip file %s: %v", filename, err)
+			continue
 		}
-		filesData = append(filesData, f)
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// parseDataFile takes x-axis and series values of data file from its properties.
+func (w *WebdavClient) parseDataFile(filename string, f MetricFileJSON) (dataFile, error) {
+	value, err := findProperty(f, w.cfg.XAxis.property())
+	if err != nil {
+		return dataFile{}, err
 	}
+	file := dataFile{filename: filename, data: f, x: axisValue{raw: value}}
 
-	result := &TemplateData{}
+	if w.cfg.Series.Property != "" {
+		groupValue, err := findProperty(f, w.cfg.Series.Property)
+		if err != nil {
+			return dataFile{}, err
+		}
+		file.group = axisValue{raw: groupValue}
+	}
+	return file, nil
+}
+
+func (w *WebdavClient) collectTemplateData(files []dataFile, reportCfg *ConfigFileJSON) (*TemplateData, error) {
+	result := &TemplateData{ChartConfig: []ChartTemplate{}}
 	result.GitBranch = w.cfg.Git.Branch
 	result.GitCommitHash = w.cfg.Git.Hash
-	result.xAxis.Name = "Nodes count"
+	result.Directory = w.cfg.Webdav.Directory
+	if result.GitBranch == "" && result.GitCommitHash == "" && reportCfg.Run != nil {
+		result.GitBranch = reportCfg.Run.Branch
+		result.GitCommitHash = reportCfg.Run.Hash
+	}
+	result.Components = runComponents(w.cfg.Components, reportCfg.Run, result.GitBranch, result.GitCommitHash)
+	result.xAxis.Property = w.cfg.XAxis.property()
+	result.xAxis.Data = []string{}
+
+	warns := &warnings{}
+	for _, f := range files {
+		for _, msg := range f.data.Warnings {
+			warns.add("%s: %s", f.filename, msg)
+		}
+	}
+
+	if len(files) == 0 {
+		warns.add("no data files with property %s", w.cfg.XAxis.property())
+		result.xAxis.Name = w.cfg.XAxis.name("")
+		result.Warnings = warns.list
+		return result, nil
+	}
+
+	unit, numeric := sortDataFiles(files)
+
+	xValue := func(f dataFile) string {
+		if numeric {
+			// unit goes to axis name
+			return strconv.FormatFloat(f.x.number, 'f', -1, 64)
+		}
+		return f.x.raw
+	}
+
+	xValues := make([]string, 0, len(files))
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		if x := xValue(f); !seen[x] {
+			seen[x] = true
+			xValues = append(xValues, x)
+		}
+	}
+	groups := groupDataFiles(files, xValues, xValue, warns)
+
+	result.xAxis.Name = w.cfg.XAxis.name(unit)
 	result.xAxis.Data = append(result.xAxis.Data, xValues...)
 
-	var ct ChartTemplate
-	for i, v := range filesData[0].Records {
-		var quantiles []string
-		switch {
-		case v.Quantile == reportCfg.Quantiles[0]:
-			quantiles = append(quantiles, reportCfg.Quantiles...)
-		case v.Quantile == "":
-			quantiles = append(quantiles, "")
-		default:
-			continue
+	values := make(map[*dataFile]map[recordKey]float64, len(files))
+	for _, g := range groups {
+		for _, f := range g.files {
+			if f != nil {
+				values[f] = recordValues(*f, warns)
+			}
 		}
+	}
 
-		ct = ChartTemplate{
-			Name:        v.Chart,
-			Description: v.Description,
-			YAxisName:   v.Unit,
+	for _, chart := range collectCharts(files, reportCfg, warns) {
+		ct := ChartTemplate{
+			Name:        chart.name,
+			Description: chart.description,
+			YAxisName:   chart.unit,
 		}
 
-		for j, q := range quantiles {
-			serie1 := SeriesTemplate{
-				Name: q,
-				Data: make([]float64, 0),
+		for _, g := range groups {
+			for _, q := range chart.quantiles {
+				serie := SeriesTemplate{
+					Name:  q,
+					Group: w.cfg.Series.label(g.value),
+					Data:  make([]*float64, 0, len(xValues)),
+				}
+				for _, f := range g.files {
+					if f == nil {
+						serie.Data = append(serie.Data, nil)
+						continue
+					}
+
+					value, ok := values[f][recordKey{chart: chart.name, quantile: q}]
+					if !ok {
+						warns.add("chart %s%s has no value in %s", chart.name, quantileLabel(q), f.filename)
+						serie.Data = append(serie.Data, nil)
+						continue
+					}
+					serie.Data = append(serie.Data, &value)
+				}
+				ct.Series = append(ct.Series, serie)
 			}
-			for k := 0; k < len(xValues); k++ {
-				// index [i+j] is used because records  for all quantiles go consistently
-				serie1.Data = append(serie1.Data, filesData[k].Records[i+j].Value)
+
+			if w.cfg.Series.panels() {
+				panel := ct
+				panel.Name = chart.name + "_" + g.value
+				panel.Metric = chart.name
+				panel.Description = w.cfg.Series.label(g.value)
+				if chart.description != "" {
+					panel.Description = chart.description + ", " + panel.Description
+				}
+				result.ChartConfig = append(result.ChartConfig, panel)
+				ct.Series = nil
 			}
-			ct.Series = append(ct.Series, serie1)
 		}
 
-		result.ChartConfig = append(result.ChartConfig, ct)
+		if !w.cfg.Series.panels() {
+			result.ChartConfig = append(result.ChartConfig, ct)
+		}
 	}
 
+	result.Warnings = warns.list
 	return result, nil
 }
 
+// Lock takes exclusive lock of report directory for writing.
+func (w *WebdavClient) Lock(breakLock bool) (*storage.Lock, error) {
+	return storage.AcquireLock(w.fs, w.cfg.Webdav.LockConfig(breakLock))
+}
+
+// DirectoryURL returns webdav URL of report directory.
+func (w *WebdavClient) DirectoryURL() string {
+	return strings.TrimRight(w.cfg.Webdav.Host, "/") + strings.TrimRight(path.Join("/", w.cfg.Webdav.Directory), "/")
+}
+
+// ReportURL returns webdav URL of html report.
+func (w *WebdavClient) ReportURL() string {
+	return w.DirectoryURL() + "/" + DefaultReportFileName
+}
+
 func (w *WebdavClient) WriteReport(data []byte) error {
-	return w.fs.Write(path.Join(w.cfg.Webdav.Directory, DefaultReportFileName), data, 0644)
+	return w.WriteReportFile(DefaultReportFileName, data)
+}
+
+// WriteReportFile saves file to report directory, e.g. exported data next to index.html.
+func (w *WebdavClient) WriteReportFile(filename string, data []byte) error {
+	return w.fs.Write(path.Join(w.cfg.Webdav.Directory, filename), data, 0644)
 }
@@ -1,11 +1,20 @@
 package report
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
 	"github.com/insolar/consensus-reports/pkg/middleware"
 )
 
@@ -28,3 +37,291 @@ func TestWebdavClient_ReadReportData(t *testing.T) {
 	_, err := c.ReadTemplateData()
 	assert.NoError(t, err)
 }
+
+type fileInfo struct {
+	name  string
+	isDir bool
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return 0 }
+func (fi fileInfo) Mode() os.FileMode  { return 0644 }
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return fi.isDir }
+func (fi fileInfo) Sys() interface{}   { return nil }
+
+// memFS keeps files by absolute path, directories are implied by file paths.
+type memFS map[string][]byte
+
+func (fs memFS) ReadDir(dir string) ([]os.FileInfo, error) {
+	prefix := "/" + strings.Trim(dir, "/") + "/"
+	seen := map[string]bool{}
+	var entries []os.FileInfo
+	for p := range fs {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(p, prefix)
+		name := strings.Split(rest, "/")[0]
+		if !seen[name] {
+			seen[name] = true
+			entries = append(entries, fileInfo{name: name, isDir: strings.Contains(rest, "/")})
+		}
+	}
+	if len(entries) == 0 {
+		return nil, &os.PathError{Op: "ReadDir", Path: dir, Err: os.ErrNotExist}
+	}
+	return entries, nil
+}
+
+func (fs memFS) Read(p string) ([]byte, error) {
+	data, ok := fs[path.Join("/", p)]
+	if !ok {
+		return nil, &os.PathError{Op: "Read", Path: p, Err: os.ErrNotExist}
+	}
+	return data, nil
+}
+
+func (fs memFS) Write(p string, data []byte, _ os.FileMode) error {
+	fs[path.Join("/", p)] = data
+	return nil
+}
+
+func (fs memFS) Remove(p string) error {
+	delete(fs, path.Join("/", p))
+	return nil
+}
+
+// ReadVersion uses file content as its version.
+func (fs memFS) ReadVersion(p string) ([]byte, string, error) {
+	data, err := fs.Read(p)
+	return data, string(data), err
+}
+
+func (fs memFS) WriteIfMatch(p string, data []byte, version string) (string, error) {
+	current, ok := fs[path.Join("/", p)]
+	if ok != (version != "") || string(current) != version {
+		return "", &os.PathError{Op: "WriteIfMatch", Path: p, Err: fmt.Errorf("412")}
+	}
+	fs[path.Join("/", p)] = data
+	return string(data), nil
+}
+
+func (fs memFS) RemoveIfMatch(p, version string) error {
+	if string(fs[path.Join("/", p)]) != version {
+		return &os.PathError{Op: "RemoveIfMatch", Path: p, Err: fmt.Errorf("412")}
+	}
+	return fs.Remove(p)
+}
+
+// loadTestData copies files from test_data to dir of fs.
+func loadTestData(t *testing.T, fs memFS, dir string) {
+	files, err := ioutil.ReadDir("test_data")
+	require.NoError(t, err)
+	for _, f := range files {
+		data, err := ioutil.ReadFile(path.Join("test_data", f.Name()))
+		require.NoError(t, err)
+		fs[path.Join("/", dir, f.Name())] = data
+	}
+}
+
+func newTestClient(fs memFS, dir string) *WebdavClient {
+	cfg := Config{}
+	cfg.Webdav.Directory = dir
+	cfg.Git.Branch = "master"
+	cfg.Git.Hash = "aabbcc"
+	return &WebdavClient{cfg: cfg, fs: fs}
+}
+
+func TestWebdavClient_ReadTemplateData(t *testing.T) {
+	fs := memFS{}
+	loadTestData(t, fs, "run")
+
+	data, err := newTestClient(fs, "run").ReadTemplateData()
+	require.NoError(t, err)
+	require.Equal(t, "Nodes count", data.xAxis.Name)
+	require.Equal(t, []string{"5", "10", "15", "17"}, data.xAxis.Data)
+	require.Len(t, data.ChartConfig, 9)
+	require.Equal(t, "sent_traffic_per_node", data.ChartConfig[0].Name)
+	require.Len(t, data.ChartConfig[0].Series, 4)
+	require.Len(t, data.ChartConfig[0].Series[0].Data, 4)
+}
+
+// seriesValues returns series data with nil for missing values.
+func seriesValues(s SeriesTemplate) []interface{} {
+	values := make([]interface{}, 0, len(s.Data))
+	for _, v := range s.Data {
+		if v == nil {
+			values = append(values, nil)
+			continue
+		}
+		values = append(values, *v)
+	}
+	return values
+}
+
+// writeDataFile writes data file with one record for every quantile.
+func writeDataFile(t *testing.T, fs memFS, filename string, quantiles []string, props ...metricreplicator.NetworkProperty) {
+	f := metricreplicator.ResultData{Properties: props}
+	for i, q := range quantiles {
+		f.Records = append(f.Records, metricreplicator.RecordInfo{
+			Chart: "phase2_duration", Unit: "ms", Quantile: q, Value: float64(i + 1),
+		})
+	}
+	data, err := json.Marshal(f)
+	require.NoError(t, err)
+	fs[path.Join("/run", filename)] = data
+}
+
+func TestWebdavClient_XAxisProperty(t *testing.T) {
+	quantiles := []string{"0.5", "0.8"}
+	fs := memFS{"/run/config.json": []byte(`{"charts":["phase2_duration"],"quantiles":["0.5","0.8"]}`)}
+	writeDataFile(t, fs, "latency_100ms_network_size_5.json", quantiles,
+		metricreplicator.NetworkProperty{Name: "latency", Value: "100ms"},
+		metricreplicator.NetworkProperty{Name: "network_size", Value: "5"})
+	writeDataFile(t, fs, "latency_50ms_network_size_5.json", quantiles,
+		metricreplicator.NetworkProperty{Name: "latency", Value: "50ms"},
+		metricreplicator.NetworkProperty{Name: "network_size", Value: "5"})
+	writeDataFile(t, fs, "loss_network_size_5.json", quantiles,
+		metricreplicator.NetworkProperty{Name: "network_size", Value: "5"})
+
+	t.Run("numeric with unit", func(t *testing.T) {
+		client := newTestClient(fs, "run")
+		client.cfg.XAxis = XAxisConfig{Property: "latency", Name: "Latency"}
+		data, err := client.ReadTemplateData()
+		require.NoError(t, err)
+		require.Equal(t, "Latency, ms", data.xAxis.Name)
+		require.Equal(t, []string{"50", "100"}, data.xAxis.Data)
+		require.Len(t, data.ChartConfig, 1)
+		require.Equal(t, []interface{}{1.0, 1.0}, seriesValues(data.ChartConfig[0].Series[0]))
+	})
+	t.Run("categorical", func(t *testing.T) {
+		fs := memFS{"/run/config.json": fs["/run/config.json"]}
+		writeDataFile(t, fs, "a.json", quantiles, metricreplicator.NetworkProperty{Name: "loss", Value: "low"})
+		writeDataFile(t, fs, "b.json", quantiles, metricreplicator.NetworkProperty{Name: "loss", Value: "high"})
+		client := newTestClient(fs, "run")
+		client.cfg.XAxis = XAxisConfig{Property: "loss"}
+		data, err := client.ReadTemplateData()
+		require.NoError(t, err)
+		require.Equal(t, "loss", data.xAxis.Name)
+		require.Equal(t, []string{"low", "high"}, data.xAxis.Data)
+	})
+}
+
+func TestWebdavClient_SeriesProperty(t *testing.T) {
+	quantiles := []string{"0.5", "0.8"}
+	fs := memFS{"/run/config.json": []byte(`{"charts":["phase2_duration"],"quantiles":["0.5","0.8"]}`)}
+	for _, f := range []struct{ latency, size string }{{"50ms", "5"}, {"50ms", "10"}, {"100ms", "10"}, {"20ms", "15"}} {
+		writeDataFile(t, fs, "latency_"+f.latency+"_network_size_"+f.size+".json", quantiles,
+			metricreplicator.NetworkProperty{Name: "latency", Value: f.latency},
+			metricreplicator.NetworkProperty{Name: "network_size", Value: f.size})
+	}
+
+	t.Run("series", func(t *testing.T) {
+		client := newTestClient(fs, "run")
+		client.cfg.Series = SeriesConfig{Property: "latency"}
+		data, err := client.ReadTemplateData()
+		require.NoError(t, err)
+		require.Equal(t, []string{"5", "10", "15"}, data.xAxis.Data)
+		require.Len(t, data.ChartConfig, 1)
+
+		series := data.ChartConfig[0].Series
+		require.Len(t, series, 6)
+		require.Equal(t, "0.5", series[0].Name)
+		require.Equal(t, "latency 20ms", series[0].Group)
+		require.Equal(t, []interface{}{nil, nil, 1.0}, seriesValues(series[0]))
+		require.Equal(t, "0.8", series[3].Name)
+		require.Equal(t, "latency 50ms", series[3].Group)
+		require.Equal(t, []interface{}{2.0, 2.0, nil}, seriesValues(series[3]))
+		require.Equal(t, "latency 100ms", series[4].Group)
+		require.Equal(t, []interface{}{nil, 1.0, nil}, seriesValues(series[4]))
+	})
+	t.Run("panels", func(t *testing.T) {
+		client := newTestClient(fs, "run")
+		client.cfg.Series = SeriesConfig{Property: "latency", Layout: SeriesLayoutPanels}
+		data, err := client.ReadTemplateData()
+		require.NoError(t, err)
+		require.Len(t, data.ChartConfig, 3)
+		require.Equal(t, "phase2_duration_20ms", data.ChartConfig[0].Name)
+		require.Equal(t, "latency 20ms", data.ChartConfig[0].Description)
+		require.Len(t, data.ChartConfig[0].Series, 2)
+		require.Equal(t, "phase2_duration_100ms", data.ChartConfig[2].Name)
+	})
+}
+
+func TestWebdavClient_RecordMismatch(t *testing.T) {
+	fs := memFS{"/run/config.json": []byte(`{"charts":["phase2_duration","phase3_duration"],"quantiles":["0.5","0.8"]}`)}
+	writeDataFile(t, fs, "network_size_5.json", []string{"0.8", "0.5"},
+		metricreplicator.NetworkProperty{Name: "network_size", Value: "5"})
+	writeDataFile(t, fs, "network_size_10.json", []string{"0.5"},
+		metricreplicator.NetworkProperty{Name: "network_size", Value: "10"})
+	writeDataFile(t, fs, "network_size_15.json", []string{"0.5", "0.99", "0.8"},
+		metricreplicator.NetworkProperty{Name: "network_size", Value: "15"})
+
+	data, err := newTestClient(fs, "run").ReadTemplateData()
+	require.NoError(t, err)
+	require.Equal(t, []string{"5", "10", "15"}, data.xAxis.Data)
+	require.Len(t, data.ChartConfig, 1)
+
+	series := data.ChartConfig[0].Series
+	require.Len(t, series, 3)
+	require.Equal(t, "0.5", series[0].Name)
+	require.Equal(t, []interface{}{2.0, 1.0, 1.0}, seriesValues(series[0]))
+	require.Equal(t, "0.8", series[1].Name)
+	require.Equal(t, []interface{}{1.0, nil, 3.0}, seriesValues(series[1]))
+	require.Equal(t, "0.99", series[2].Name)
+	require.Equal(t, []interface{}{nil, nil, 2.0}, seriesValues(series[2]))
+
+	require.Contains(t, data.Warnings, "chart phase2_duration quantile 0.8 has no value in network_size_10.json")
+	require.Contains(t, data.Warnings, "chart phase3_duration has no records")
+}
+
+func TestWebdavClient_EmptyDirectory(t *testing.T) {
+	fs := memFS{"/run/config.json": []byte(`{"charts":["phase2_duration"],"quantiles":["0.5"]}`)}
+
+	data, err := newTestClient(fs, "run").ReadTemplateData()
+	require.NoError(t, err)
+	require.Empty(t, data.ChartConfig)
+	require.Equal(t, []string{"no data files with property network_size"}, data.Warnings)
+
+	err = MakeReport(newTestClient(fs, "run"), &bytes.Buffer{}, Options{CDN: true})
+	require.NoError(t, err)
+}
+
+func TestMakeReport_ChartLibrary(t *testing.T) {
+	fs := memFS{}
+	loadTestData(t, fs, "run")
+
+	t.Run("cdn", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := MakeReport(newTestClient(fs, "run"), buf, Options{CDN: true})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), `<script src="`+ChartLibraryURL+`"></script>`)
+	})
+	t.Run("not bundled", func(t *testing.T) {
+		defer func(lib string) { chartLibraryFile = lib }(chartLibraryFile)
+		chartLibraryFile = "/pkg/report/assets/missing.js"
+
+		err := MakeReport(newTestClient(fs, "run"), &bytes.Buffer{}, Options{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "chart library is not bundled")
+		require.Error(t, Options{}.Validate())
+		require.NoError(t, Options{CDN: true}.Validate())
+	})
+	t.Run("inline", func(t *testing.T) {
+		defer func(lib string) { chartLibraryFile = lib }(chartLibraryFile)
+		// any embedded file with closing script tags works as a library
+		chartLibraryFile = "/pkg/report/template.html"
+
+		library, err := chartLibrary()
+		require.NoError(t, err)
+		require.Contains(t, library, `<\/script>`)
+		require.NotContains(t, library, "</script")
+
+		buf := &bytes.Buffer{}
+		err = MakeReport(newTestClient(fs, "run"), buf, Options{})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), library)
+		require.NotContains(t, buf.String(), ChartLibraryURL)
+	})
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+)
+
+const DefaultXAxisProperty = "network_size"
+
+// XAxisConfig sets range property used as x-axis of charts.
+type XAxisConfig struct {
+	// Property is a name of range property, default is network_size.
+	Property string `mapstructure:"property"`
+	// Name is an axis title, default is property name.
+	Name string `mapstructure:"name"`
+}
+
+var knownAxisNames = map[string]string{
+	DefaultXAxisProperty: "Nodes count",
+}
+
+func (cfg XAxisConfig) property() string {
+	if cfg.Property == "" {
+		return DefaultXAxisProperty
+	}
+	return cfg.Property
+}
+
+func (cfg XAxisConfig) name(unit string) string {
+	name := cfg.Name
+	if name == "" {
+		name = knownAxisNames[cfg.property()]
+	}
+	if name == "" {
+		name = cfg.property()
+	}
+	if unit != "" {
+		name += ", " + unit
+	}
+	return name
+}
+
+// numberWithUnitRegexp matches property values like "5", "0.5" or "50ms".
+var numberWithUnitRegexp = regexp.MustCompile(`^\s*(-?[0-9]+(?:\.[0-9]+)?)\s*([^0-9\s]*)\s*$`)
+
+type axisValue struct {
+	raw    string
+	number float64
+	unit   string
+}
+
+func parseAxisValue(raw string) (axisValue, bool) {
+	match := numberWithUnitRegexp.FindStringSubmatch(raw)
+	if match == nil {
+		return axisValue{raw: raw}, false
+	}
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return axisValue{raw: raw}, false
+	}
+	return axisValue{raw: raw, number: number, unit: match[2]}, true
+}
+
+// sortDataFiles sorts files by x-axis value numerically if all values are numbers with the same unit
+// and returns the unit. Files with categorical values keep their order.
+func sortDataFiles(files []dataFile) (string, bool) {
+	if len(files) == 0 {
+		return "", false
+	}
+
+	unit := ""
+	for i, f := range files {
+		parsed, ok := parseAxisValue(f.x.raw)
+		if !ok || (i > 0 && parsed.unit != unit) {
+			return "", false
+		}
+		unit = parsed.unit
+	}
+
+	for i := range files {
+		files[i].x, _ = parseAxisValue(files[i].x.raw)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].x.number < files[j].x.number
+	})
+	return unit, true
+}
+
+func findProperty(f MetricFileJSON, name string) (string, error) {
+	for _, props := range [][]metricreplicator.NetworkProperty{f.Properties, f.Network} {
+		for _, p := range props {
+			if p.Name == name {
+				return p.Value, nil
+			}
+		}
+	}
+	return "", errors.Errorf("no property %s", name)
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+const apiRunsPath = "/api/runs"
+
+// apiRuns serves run list at /api/runs, charts of run at /api/runs/<name>/charts
+// and its raw data files at /api/runs/<name>/raw. Run names can contain slashes.
+// Charts and files are filtered by repeated or comma separated chart and quantile parameters.
+func (s *Server) apiRuns(w http.ResponseWriter, r *http.Request) {
+	if s.runs == nil {
+		s.apiError(w, http.StatusNotFound, errors.New("runs browsing is off, set serve root"))
+		return
+	}
+
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, apiRunsPath), "/")
+	if name == "" {
+		runs, err := s.listRuns()
+		if err != nil {
+			log.Printf("failed to list runs: %v", err)
+			s.apiError(w, http.StatusBadGateway, err)
+			return
+		}
+		s.writeJSON(w, r, runs)
+		return
+	}
+
+	filter := report.DataFilter{Charts: queryList(r, "chart"), Quantiles: queryList(r, "quantile")}
+	switch {
+	case strings.HasSuffix(name, "/charts"):
+		s.apiCharts(w, r, strings.TrimSuffix(name, "/charts"), filter)
+	case strings.HasSuffix(name, "/raw"):
+		s.apiRaw(w, r, strings.TrimSuffix(name, "/raw"), filter)
+	default:
+		s.apiError(w, http.StatusNotFound, errors.Errorf("unknown api path %s", r.URL.Path))
+	}
+}
+
+func (s *Server) apiCharts(w http.ResponseWriter, r *http.Request, name string, filter report.DataFilter) {
+	reader, err := s.runs.RunReader(name)
+	if err != nil {
+		s.apiError(w, http.StatusBadRequest, err)
+		return
+	}
+	data, err := s.cache.templateData("run:"+name, reader)
+	if err != nil {
+		log.Printf("failed to read run %s: %v", name, err)
+		s.apiError(w, http.StatusBadGateway, err)
+		return
+	}
+	s.writeJSON(w, r, filter.FilterCharts(data))
+}
+
+func (s *Server) apiRaw(w http.ResponseWriter, r *http.Request, name string, filter report.DataFilter) {
+	value, err := s.cache.get("raw:"+name, func() (interface{}, error) {
+		return s.runs.RawFiles(name)
+	})
+	if err != nil {
+		log.Printf("failed to read raw files of run %s: %v", name, err)
+		s.apiError(w, http.StatusBadGateway, err)
+		return
+	}
+	s.writeJSON(w, r, filter.FilterFiles(value.([]report.RawFile)))
+}
+
+// queryList returns values of repeated or comma separated query parameter.
+func queryList(r *http.Request, key string) []string {
+	var result []string
+	for _, v := range r.URL.Query()[key] {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	buf, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		s.apiError(w, http.StatusInternalServerError, errors.Wrap(err, "failed to marshal json"))
+		return
+	}
+	writeWithETag(w, r, "application/json", append(buf, '\n'))
+}
+
+func (s *Server) apiError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
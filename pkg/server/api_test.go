@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+func value(v float64) *float64 {
+	return &v
+}
+
+func TestServer_API(t *testing.T) {
+	data := &report.TemplateData{
+		GitBranch: "master",
+		ChartConfig: []report.ChartTemplate{
+			{Name: "phase2_duration", Series: []report.SeriesTemplate{
+				{Name: "0.5", Data: []*float64{value(1)}},
+				{Name: "0.99", Data: []*float64{value(2)}},
+			}},
+			{Name: "phase3_duration", Series: []report.SeriesTemplate{
+				{Name: "0.5", Data: []*float64{value(3)}},
+			}},
+		},
+	}
+	runs := testRuns{
+		runs: []report.RunSummary{{Name: "master/aaaaaaaaaa", Branch: "master", Hash: "aaaaaaaaaa"}},
+		readers: map[string]*countingReader{
+			"master/aaaaaaaaaa":  {data: data},
+			"feature/bbbbbbbbbb": {err: errors.New("index is broken")},
+		},
+		files: map[string][]report.RawFile{
+			"master/aaaaaaaaaa": {{Filename: "network_size_5.json", Data: report.MetricFileJSON{
+				Records: []metricreplicator.RecordInfo{
+					{Chart: "phase2_duration", Quantile: "0.5", Value: 1},
+					{Chart: "phase3_duration", Quantile: "0.5", Value: 3},
+				},
+			}}},
+		},
+	}
+	s := New(report.ServeConfig{}, &countingReader{}, report.Options{CDN: true}).Browse(runs)
+
+	rec := get(t, s, "/api/runs", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var list []report.RunSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Equal(t, runs.runs, list)
+
+	rec = get(t, s, "/api/runs/master/aaaaaaaaaa/charts?chart=phase2_duration&quantile=0.99", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var charts report.ChartsJSON
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &charts))
+	require.Equal(t, "master", charts.Branch)
+	require.Equal(t, []report.ChartTemplate{
+		{Name: "phase2_duration", Series: []report.SeriesTemplate{{Name: "0.99", Data: []*float64{value(2)}}}},
+	}, charts.Charts)
+
+	// the same charts are read from cache
+	rec = get(t, s, "/api/runs/master/aaaaaaaaaa/charts?chart=phase2_duration,phase3_duration", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &charts))
+	require.Len(t, charts.Charts, 2)
+	require.Equal(t, 1, runs.readers["master/aaaaaaaaaa"].reads)
+
+	rec = get(t, s, "/api/runs/master/aaaaaaaaaa/raw?chart=phase3_duration", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var files []report.RawFile
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &files))
+	require.Len(t, files, 1)
+	require.Equal(t, []metricreplicator.RecordInfo{{Chart: "phase3_duration", Quantile: "0.5", Value: 3}}, files[0].Data.Records)
+
+	rec = get(t, s, "/api/runs/feature/bbbbbbbbbb/charts", nil)
+	require.Equal(t, http.StatusBadGateway, rec.Code)
+	require.JSONEq(t, `{"error": "index is broken"}`, rec.Body.String())
+
+	rec = get(t, s, "/api/runs/master/aaaaaaaaaa/unknown", nil)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServer_APIWithoutBrowsing(t *testing.T) {
+	s := New(report.ServeConfig{}, &countingReader{}, report.Options{CDN: true})
+
+	rec := get(t, s, "/api/runs", nil)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Contains(t, rec.Body.String(), "serve root")
+}
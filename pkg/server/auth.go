@@ -0,0 +1,49 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const authRealm = "consensus reports"
+
+// authorized checks basic auth or bearer token of request, requests are allowed if auth is not set.
+func (s *Server) authorized(r *http.Request) bool {
+	auth := s.cfg.Auth
+	if auth.Username == "" && auth.Token == "" {
+		return true
+	}
+
+	if auth.Token != "" {
+		header := r.Header.Get("Authorization")
+		if strings.HasPrefix(header, "Bearer ") && secretEqual(strings.TrimPrefix(header, "Bearer "), auth.Token) {
+			return true
+		}
+	}
+	if auth.Username != "" {
+		username, password, ok := r.BasicAuth()
+		// both are compared, so response time doesn't tell which one is wrong
+		userOK := secretEqual(username, auth.Username)
+		passwordOK := secretEqual(password, auth.Password)
+		if ok && userOK && passwordOK {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) unauthorized(w http.ResponseWriter) {
+	if s.cfg.Auth.Username != "" {
+		w.Header().Set("WWW-Authenticate", `Basic realm="`+authRealm+`", charset="UTF-8"`)
+	} else {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+authRealm+`"`)
+	}
+	s.errorPage(w, http.StatusUnauthorized, errors.New("authorization is required"))
+}
+
+func secretEqual(given, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
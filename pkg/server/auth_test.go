@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+func TestServer_Auth(t *testing.T) {
+	cfg := report.ServeConfig{Auth: report.ServeAuthConfig{Username: "admin", Password: "s3cret", Token: "t0ken"}}
+	s := New(cfg, &countingReader{data: testData()}, report.Options{CDN: true})
+
+	rec := get(t, s, "/", nil)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("admin", "wrong")
+	rec = get(t, s, "/", req.Header)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req.SetBasicAuth("admin", "s3cret")
+	rec = get(t, s, "/", req.Header)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = get(t, s, "/", http.Header{"Authorization": {"Bearer wrong"}})
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = get(t, s, "/", http.Header{"Authorization": {"Bearer t0ken"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// probes don't have credentials
+	rec = get(t, s, "/healthz", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_TokenAuth(t *testing.T) {
+	cfg := report.ServeConfig{Auth: report.ServeAuthConfig{Token: "t0ken"}}
+	s := New(cfg, &countingReader{data: testData()}, report.Options{CDN: true})
+
+	rec := get(t, s, "/api/runs", nil)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+
+	rec = get(t, s, "/", http.Header{"Authorization": {"Bearer t0ken"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+const runsPath = "/runs/"
+
+// runRow is a run of index page with link to comparison with previous run of the same branch.
+type runRow struct {
+	report.RunSummary
+	URL      string
+	Previous string
+}
+
+func (r runRow) ShortHash() string {
+	if len(r.Hash) > 8 {
+		return r.Hash[:8]
+	}
+	return r.Hash
+}
+
+func runURL(name string) string {
+	return runsPath + name
+}
+
+func compareURL(baseline, candidate string) string {
+	return "/compare?" + url.Values{"baseline": {baseline}, "candidate": {candidate}}.Encode()
+}
+
+// runRows returns rows of runs sorted newest first.
+func runRows(runs []report.RunSummary) []runRow {
+	rows := make([]runRow, 0, len(runs))
+	for i, run := range runs {
+		row := runRow{RunSummary: run, URL: runURL(run.Name)}
+		for _, older := range runs[i+1:] {
+			if older.Branch == run.Branch {
+				row.Previous = compareURL(older.Name, run.Name)
+				break
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (s *Server) listRuns() ([]report.RunSummary, error) {
+	value, err := s.cache.get("runs", func() (interface{}, error) {
+		return s.runs.ListRuns()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]report.RunSummary), nil
+}
+
+func (s *Server) runList(w http.ResponseWriter, r *http.Request) {
+	runs, err := s.listRuns()
+	if err != nil {
+		log.Printf("failed to list runs: %v", err)
+		s.errorPage(w, http.StatusBadGateway, err)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if err := runsTemplate.Execute(buf, runRows(runs)); err != nil {
+		log.Printf("failed to make runs page: %v", err)
+		s.errorPage(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeWithETag(w, r, "text/html; charset=utf-8", buf.Bytes())
+}
+
+func (s *Server) run(w http.ResponseWriter, r *http.Request) {
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, runsPath), "/")
+	reader, err := s.runs.RunReader(name)
+	if err != nil {
+		s.errorPage(w, http.StatusBadRequest, err)
+		return
+	}
+	s.report(w, r, "run:"+name, reader)
+}
+
+func (s *Server) compare(w http.ResponseWriter, r *http.Request) {
+	baseline, candidate := r.URL.Query().Get("baseline"), r.URL.Query().Get("candidate")
+	if baseline == "" || candidate == "" {
+		s.errorPage(w, http.StatusBadRequest, errors.New("baseline and candidate runs are required"))
+		return
+	}
+	reader, err := s.runs.CompareReader(baseline, candidate)
+	if err != nil {
+		s.errorPage(w, http.StatusBadRequest, err)
+		return
+	}
+	s.report(w, r, "compare:"+baseline+"\n"+candidate, reader)
+}
+
+var runsTemplate = template.Must(template.New("runs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>Consensus performance runs</title>
+    <style>
+        body { font-family: sans-serif; }
+        table { border-collapse: collapse; }
+        th, td { padding: 4px 12px; text-align: left; border-bottom: 1px solid #ddd; }
+        .pass { color: #2e7d32; }
+        .regression, .error { color: #b94a48; font-weight: bold; }
+    </style>
+</head>
+<body>
+<h3>Consensus performance runs</h3>
+{{if .}}
+<form action="/compare" method="get">
+    Compare
+    <select name="baseline">{{range $i, $run := .}}<option value="{{$run.Name}}"{{if eq $i 1}} selected{{end}}>{{$run.Name}}</option>{{end}}</select>
+    with
+    <select name="candidate">{{range .}}<option value="{{.Name}}">{{.Name}}</option>{{end}}</select>
+    <input type="submit" value="Compare">
+</form>
+<table>
+    <tr><th>Run</th><th>Branch</th><th>Hash</th><th>Commit date</th><th>Date</th><th>Verdict</th><th></th></tr>
+    {{range .}}
+    <tr>
+        <td><a href="{{.URL}}">{{.Name}}</a></td>
+        <td>{{.Branch}}</td>
+        <td title="{{.Hash}}">{{.ShortHash}}</td>
+        <td>{{.CommitDate.Format "2006-01-02 15:04"}}</td>
+        <td>{{.Date.Format "2006-01-02 15:04"}}</td>
+        <td class="{{.Verdict}}">{{if .Verdict}}{{.Verdict}}{{else}}-{{end}}</td>
+        <td>{{if .Previous}}<a href="{{.Previous}}">compare with previous</a>{{end}}</td>
+    </tr>
+    {{end}}
+</table>
+{{else}}
+<p>No runs found.</p>
+{{end}}
+</body>
+</html>
+`))
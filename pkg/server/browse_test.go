@@ -0,0 +1,95 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+// testRuns has runs with data readers and raw files by name.
+type testRuns struct {
+	runs    []report.RunSummary
+	readers map[string]*countingReader
+	files   map[string][]report.RawFile
+}
+
+func (r testRuns) RawFiles(name string) ([]report.RawFile, error) {
+	files, ok := r.files[name]
+	if !ok {
+		return nil, errors.Errorf("run %s is not found", name)
+	}
+	return files, nil
+}
+
+func (r testRuns) ListRuns() ([]report.RunSummary, error) {
+	return r.runs, nil
+}
+
+func (r testRuns) RunReader(name string) (report.TemplateDataReader, error) {
+	reader, ok := r.readers[name]
+	if !ok {
+		return nil, errors.Errorf("run %s is not found", name)
+	}
+	return reader, nil
+}
+
+func (r testRuns) CompareReader(baseline, candidate string) (report.TemplateDataReader, error) {
+	base, err := r.RunReader(baseline)
+	if err != nil {
+		return nil, err
+	}
+	cand, err := r.RunReader(candidate)
+	if err != nil {
+		return nil, err
+	}
+	return report.CompareReader{Baseline: base, Candidate: cand, Tolerance: 5}, nil
+}
+
+func TestServer_Browse(t *testing.T) {
+	day := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	runs := testRuns{
+		runs: []report.RunSummary{
+			{Name: "master/cccccccccc", Branch: "master", Hash: "cccccccccc", Date: day.Add(2 * time.Hour), CommitDate: day},
+			{Name: "feature/bbbbbbbbbb", Branch: "feature", Hash: "bbbbbbbbbb", Date: day.Add(time.Hour), CommitDate: day},
+			{Name: "master/aaaaaaaaaa", Branch: "master", Hash: "aaaaaaaaaa", Date: day, CommitDate: day, Verdict: report.CheckStatusPass},
+		},
+		readers: map[string]*countingReader{
+			"master/cccccccccc":  {data: &report.TemplateData{GitBranch: "master", GitCommitHash: "cccccccccc"}},
+			"feature/bbbbbbbbbb": {err: errors.New("index is broken")},
+			"master/aaaaaaaaaa":  {data: &report.TemplateData{GitBranch: "master", GitCommitHash: "aaaaaaaaaa"}},
+		},
+	}
+	s := New(report.ServeConfig{}, &countingReader{}, report.Options{CDN: true}).Browse(runs)
+
+	rec := get(t, s, "/", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	require.Contains(t, body, `<a href="/runs/master/cccccccccc">master/cccccccccc</a>`)
+	require.Contains(t, body, `<td class="pass">pass</td>`)
+	require.Contains(t, body, `2020-07-01 02:00`)
+	// previous run of master
+	require.Contains(t, body, `href="/compare?baseline=master%2Faaaaaaaaaa&amp;candidate=master%2Fcccccccccc"`)
+
+	rec = get(t, s, "/runs/master/cccccccccc", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "cccccccccc")
+
+	rec = get(t, s, "/runs/feature/bbbbbbbbbb", nil)
+	require.Equal(t, http.StatusBadGateway, rec.Code)
+	require.Contains(t, rec.Body.String(), "index is broken")
+
+	rec = get(t, s, "/runs/unknown", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = get(t, s, "/compare?baseline=master/aaaaaaaaaa&candidate=master/cccccccccc", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "master@aaaaaaaa")
+
+	rec = get(t, s, "/compare?baseline=master/aaaaaaaaaa", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
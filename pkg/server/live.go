@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+const (
+	// MaxLiveRanges is maximal number of ranges of one live report request.
+	MaxLiveRanges = 64
+	// MaxLiveQuantiles is maximal number of quantiles of one live report request.
+	MaxLiveQuantiles = 16
+)
+
+// Live serves report of ranges grabbed from prometheus at /live.
+// Ranges and quantiles are taken from query or from config, results are cached per range
+// in own cache, so requests of many ranges don't evict reports of runs.
+func (s *Server) Live(live report.LiveSource) *Server {
+	rangeCache := newCache(cacheTTL(s.cfg), cacheSize(s.cfg), time.Now)
+	s.live = live.WithGrabber(cachedGrabber{cache: rangeCache, grabber: live.Grabber()})
+	s.mux.HandleFunc("/live", s.liveReport)
+	return s
+}
+
+// liveReport serves /live?range=<start>,<interval>,<name>=<value>&quantile=<quantile>&description=<text>,
+// range can be repeated.
+func (s *Server) liveReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	periods := s.live.Periods()
+	if ranges := query["range"]; len(ranges) > 0 {
+		if len(ranges) > MaxLiveRanges {
+			s.errorPage(w, http.StatusBadRequest, errors.Errorf("too many ranges, maximum is %d", MaxLiveRanges))
+			return
+		}
+		periods = make([]replicator.PeriodInfo, 0, len(ranges))
+		for _, value := range ranges {
+			period, err := report.ParseLiveRange(value, query.Get("description"))
+			if err != nil {
+				s.errorPage(w, http.StatusBadRequest, err)
+				return
+			}
+			periods = append(periods, period)
+		}
+	}
+	if len(periods) == 0 {
+		s.errorPage(w, http.StatusBadRequest, errors.New("ranges are required, set range parameter or live groups of config"))
+		return
+	}
+
+	quantiles := s.live.Quantiles()
+	if values := queryList(r, "quantile"); len(values) > 0 {
+		if len(values) > MaxLiveQuantiles {
+			s.errorPage(w, http.StatusBadRequest, errors.Errorf("too many quantiles, maximum is %d", MaxLiveQuantiles))
+			return
+		}
+		quantiles = make([]string, 0, len(values))
+		for _, value := range values {
+			q, err := report.ParseLiveQuantile(value)
+			if err != nil {
+				s.errorPage(w, http.StatusBadRequest, err)
+				return
+			}
+			quantiles = append(quantiles, q)
+		}
+	}
+
+	// template data is cheap to make from cached ranges, so it isn't cached itself
+	data, err := s.live.Reader(r.Context(), quantiles, periods).ReadTemplateData()
+	if err != nil {
+		log.Printf("failed to read live report: %v", err)
+		s.errorPage(w, http.StatusBadGateway, err)
+		return
+	}
+	s.render(w, r, "live", data)
+}
+
+// cachedGrabber keeps grabbed results of ranges in server cache.
+type cachedGrabber struct {
+	cache   *cache
+	grabber report.ResultGrabber
+}
+
+func (g cachedGrabber) GrabResult(ctx context.Context, quantiles []string, period replicator.PeriodInfo) (metricreplicator.ResultData, error) {
+	value, err := g.cache.get(rangeKey(quantiles, period), func() (interface{}, error) {
+		return g.grabber.GrabResult(ctx, quantiles, period)
+	})
+	if err != nil {
+		return metricreplicator.ResultData{}, err
+	}
+	return value.(metricreplicator.ResultData), nil
+}
+
+func (g cachedGrabber) Charts() []string {
+	return g.grabber.Charts()
+}
+
+func rangeKey(quantiles []string, period replicator.PeriodInfo) string {
+	key := fmt.Sprintf("range:%d:%d:%s:%s", period.Start.Unix(), period.End.Unix(), strings.Join(quantiles, ","), period.Description)
+	for _, props := range [][]replicator.PeriodProperty{period.Network, period.Properties} {
+		for _, p := range props {
+			key += ":" + p.Name + "=" + p.Value
+		}
+		key += ";"
+	}
+	return key
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/metricreplicator"
+	"github.com/insolar/consensus-reports/pkg/replicator"
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+// countingGrabber returns phase2 duration of network size and counts grabbed ranges.
+type countingGrabber struct {
+	mu     sync.Mutex
+	grabs  int
+	values map[string]float64
+}
+
+func (g *countingGrabber) GrabResult(_ context.Context, quantiles []string, period replicator.PeriodInfo) (metricreplicator.ResultData, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.grabs++
+
+	result := metricreplicator.ResultData{}
+	for _, p := range period.Properties {
+		result.Properties = append(result.Properties, metricreplicator.NetworkProperty{Name: p.Name, Value: p.Value})
+		for _, q := range quantiles {
+			result.Records = append(result.Records, metricreplicator.RecordInfo{Chart: "phase2_duration", Quantile: q, Value: g.values[p.Value]})
+		}
+	}
+	return result, nil
+}
+
+func (g *countingGrabber) Charts() []string {
+	return []string{"phase2_duration"}
+}
+
+func TestServer_Live(t *testing.T) {
+	cfg := report.Config{}
+	cfg.Live.Quantiles = []string{"0.5"}
+	grabber := &countingGrabber{values: map[string]float64{"5": 111, "17": 222}}
+	s := New(report.ServeConfig{}, &countingReader{}, report.Options{CDN: true}).Live(report.NewLiveSource(cfg, grabber))
+
+	rec := get(t, s, "/live", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "ranges are required")
+
+	rec = get(t, s, "/live?range=1589292280,3m,network_size=5", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "111")
+	require.Equal(t, 1, grabber.grabs)
+
+	// only new range is grabbed
+	rec = get(t, s, "/live?range=1589292280,3m,network_size=5&range=1589292580,3m,network_size=17", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "222")
+	require.Equal(t, 2, grabber.grabs)
+
+	// other quantiles are other results
+	rec = get(t, s, "/live?range=1589292280,3m,network_size=5&quantile=0.99", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 3, grabber.grabs)
+	// ranges don't take place of reports
+	require.Empty(t, s.cache.entries)
+
+	tooMany := "/live?"
+	for i := 0; i <= MaxLiveRanges; i++ {
+		tooMany += "range=1589292280,3m,network_size=5&"
+	}
+	rec = get(t, s, tooMany, nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "too many ranges")
+
+	// quantiles are formatted into prometheus queries
+	rec = get(t, s, "/live?range=1589292280,3m,network_size=5&quantile="+url.QueryEscape("0.5) or vector(1"), nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = get(t, s, "/live?range=1589292280,3m,network_size=5&quantile="+strings.Repeat("0.5,", MaxLiveQuantiles+1), nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "too many quantiles")
+	// normalized quantile is cached
+	rec = get(t, s, "/live?range=1589292280,3m,network_size=5&quantile=0.990", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 3, grabber.grabs)
+
+	rec = get(t, s, "/live?range=yesterday", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,348 @@
+package server
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"html/template"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+const (
+	DefaultCacheTTL        = time.Minute
+	DefaultCacheSize       = 256
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+func cacheTTL(cfg report.ServeConfig) time.Duration {
+	if cfg.CacheTTL == 0 {
+		return DefaultCacheTTL
+	}
+	return cfg.CacheTTL
+}
+
+func cacheSize(cfg report.ServeConfig) int {
+	if cfg.CacheSize <= 0 {
+		return DefaultCacheSize
+	}
+	return cfg.CacheSize
+}
+
+func shutdownTimeout(cfg report.ServeConfig) time.Duration {
+	if cfg.ShutdownTimeout == 0 {
+		return DefaultShutdownTimeout
+	}
+	return cfg.ShutdownTimeout
+}
+
+// Runs is a storage of runs to browse.
+type Runs interface {
+	ListRuns() ([]report.RunSummary, error)
+	RunReader(name string) (report.TemplateDataReader, error)
+	CompareReader(baseline, candidate string) (report.TemplateDataReader, error)
+	RawFiles(name string) ([]report.RawFile, error)
+}
+
+// Server serves html report of reader. Report data is cached for CacheTTL, at most CacheSize reports,
+// pages have ETag, so browsers get 304 Not Modified while data is the same.
+// Failed requests get error page, the server keeps working.
+type Server struct {
+	cfg    report.ServeConfig
+	reader report.TemplateDataReader
+	runs   Runs
+	live   report.LiveSource
+	opts   report.Options
+	cache  *cache
+	mux    *http.ServeMux
+}
+
+// New creates server of reader data rendered with opts.
+func New(cfg report.ServeConfig, reader report.TemplateDataReader, opts report.Options) *Server {
+	s := &Server{
+		cfg:    cfg,
+		reader: reader,
+		opts:   opts,
+		cache:  newCache(cacheTTL(cfg), cacheSize(cfg), time.Now),
+		mux:    http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/healthz", s.health)
+	s.mux.HandleFunc(apiRunsPath, s.apiRuns)
+	s.mux.HandleFunc(apiRunsPath+"/", s.apiRuns)
+	s.mux.HandleFunc("/", s.index)
+	return s
+}
+
+// Browse replaces report of reader with list of runs, every run has its own report page.
+func (s *Server) Browse(runs Runs) *Server {
+	s.runs = runs
+	s.mux.HandleFunc("/runs/", s.run)
+	s.mux.HandleFunc("/compare", s.compare)
+	return s
+}
+
+// ServeHTTP implements http.Handler. Health endpoint is open for probes, other requests are authorized.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/healthz" && !s.authorized(r) {
+		s.unauthorized(w)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// ListenAndServe serves requests until ctx is done, then it waits for active requests and returns.
+// Requests are served over https if tls certificate is set.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{Addr: addr, Handler: s}
+
+	errs := make(chan error, 1)
+	go func() {
+		if s.cfg.TLS.Enabled() {
+			errs <- srv.ListenAndServeTLS(s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile)
+			return
+		}
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return errors.Wrap(err, "failed to serve")
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout(s.cfg))
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return errors.Wrap(err, "failed to shut down server")
+	}
+	return nil
+}
+
+func (s *Server) health(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"status":"ok"}` + "\n"))
+}
+
+func (s *Server) index(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		s.errorPage(w, http.StatusNotFound, errors.Errorf("page %s is not found", r.URL.Path))
+		return
+	}
+	if s.runs != nil {
+		s.runList(w, r)
+		return
+	}
+	s.report(w, r, "", s.reader)
+}
+
+// report renders html report of reader data cached by key.
+func (s *Server) report(w http.ResponseWriter, r *http.Request, key string, reader report.TemplateDataReader) {
+	data, err := s.cache.templateData(key, reader)
+	if err != nil {
+		log.Printf("failed to read report %q: %v", key, err)
+		s.errorPage(w, http.StatusBadGateway, err)
+		return
+	}
+
+	s.render(w, r, key, data)
+}
+
+// render writes html report of data.
+func (s *Server) render(w http.ResponseWriter, r *http.Request, key string, data *report.TemplateData) {
+	buf := &bytes.Buffer{}
+	if err := report.MakeReport(staticReader{data}, buf, s.opts); err != nil {
+		log.Printf("failed to make report %q: %v", key, err)
+		s.errorPage(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeWithETag(w, r, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// writeWithETag writes body or 304 Not Modified if client has the same body.
+func writeWithETag(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(body)
+}
+
+var errorTemplate = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>{{.Status}}</title>
+    <style>body { font-family: sans-serif; text-align: center; } pre { color: #b94a48; white-space: pre-wrap; }</style>
+</head>
+<body>
+<h3>{{.Status}}</h3>
+<pre>{{.Error}}</pre>
+<a href="/">Back</a>
+</body>
+</html>
+`))
+
+func (s *Server) errorPage(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = errorTemplate.Execute(w, struct {
+		Status string
+		Error  string
+	}{
+		Status: http.StatusText(status),
+		Error:  err.Error(),
+	})
+}
+
+// staticReader returns already read data.
+type staticReader struct {
+	data *report.TemplateData
+}
+
+func (r staticReader) ReadTemplateData() (*report.TemplateData, error) {
+	return r.data, nil
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// keyLoad lets only one request load value of key, it is removed when nobody waits for it.
+type keyLoad struct {
+	sync.Mutex
+	waiters int
+}
+
+// cache keeps at most size loaded values by key for ttl, least recently used values are evicted first.
+// Errors are not cached.
+type cache struct {
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // of *cacheEntry, recently used first
+	loading map[string]*keyLoad
+}
+
+func newCache(ttl time.Duration, size int, now func() time.Time) *cache {
+	return &cache{
+		ttl:     ttl,
+		size:    size,
+		now:     now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		loading: make(map[string]*keyLoad),
+	}
+}
+
+func (c *cache) get(key string, load func() (interface{}, error)) (interface{}, error) {
+	if value, ok := c.lookup(key); ok {
+		return value, nil
+	}
+
+	// only one request loads value of key, others wait for it
+	c.mu.Lock()
+	keyLock, ok := c.loading[key]
+	if !ok {
+		keyLock = &keyLoad{}
+		c.loading[key] = keyLock
+	}
+	keyLock.waiters++
+	c.mu.Unlock()
+
+	keyLock.Lock()
+	defer func() {
+		keyLock.Unlock()
+		c.mu.Lock()
+		keyLock.waiters--
+		if keyLock.waiters == 0 {
+			delete(c.loading, key)
+		}
+		c.mu.Unlock()
+	}()
+	if value, ok := c.lookup(key); ok {
+		return value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(key, value)
+	return value, nil
+}
+
+func (c *cache) lookup(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// store adds value of key, removes expired values and evicts least recently used ones over size.
+func (c *cache) store(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expires: now.Add(c.ttl)})
+
+	for elem := c.order.Back(); elem != nil; {
+		prev := elem.Prev()
+		if !now.Before(elem.Value.(*cacheEntry).expires) {
+			c.remove(elem)
+		}
+		elem = prev
+	}
+	for c.size > 0 && c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *cache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// templateData returns cached template data of reader.
+func (c *cache) templateData(key string, reader report.TemplateDataReader) (*report.TemplateData, error) {
+	value, err := c.get(key, func() (interface{}, error) {
+		return reader.ReadTemplateData()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*report.TemplateData), nil
+}
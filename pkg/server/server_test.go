@@ -0,0 +1,140 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/consensus-reports/pkg/report"
+)
+
+// countingReader returns data or error and counts reads.
+type countingReader struct {
+	mu    sync.Mutex
+	data  *report.TemplateData
+	err   error
+	reads int
+}
+
+func (r *countingReader) ReadTemplateData() (*report.TemplateData, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	return r.data, r.err
+}
+
+func get(t *testing.T, h http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func testData() *report.TemplateData {
+	return &report.TemplateData{GitBranch: "master", GitCommitHash: "aabbcc"}
+}
+
+func TestServer_Health(t *testing.T) {
+	s := New(report.ServeConfig{}, &countingReader{err: errors.New("storage is down")}, report.Options{CDN: true})
+
+	rec := get(t, s, "/healthz", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestServer_ErrorPage(t *testing.T) {
+	reader := &countingReader{err: errors.New("storage is down")}
+	s := New(report.ServeConfig{}, reader, report.Options{CDN: true})
+
+	rec := get(t, s, "/", nil)
+	require.Equal(t, http.StatusBadGateway, rec.Code)
+	require.Contains(t, rec.Body.String(), "storage is down")
+
+	// errors are not cached, server keeps working when storage is back
+	reader.err = nil
+	reader.data = testData()
+	rec = get(t, s, "/", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "aabbcc")
+	require.Equal(t, 2, reader.reads)
+
+	rec = get(t, s, "/unknown", nil)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Contains(t, rec.Body.String(), "/unknown")
+}
+
+func TestServer_Cache(t *testing.T) {
+	reader := &countingReader{data: testData()}
+	s := New(report.ServeConfig{CacheTTL: time.Minute}, reader, report.Options{CDN: true})
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.cache.now = func() time.Time { return now }
+
+	first := get(t, s, "/", nil)
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	rec := get(t, s, "/", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+	require.Equal(t, 1, reader.reads)
+
+	now = now.Add(time.Minute)
+	reader.data = &report.TemplateData{GitBranch: "master", GitCommitHash: "ddeeff"}
+	rec = get(t, s, "/", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "ddeeff")
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+	require.Equal(t, 2, reader.reads)
+}
+
+func TestCache_Eviction(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	c := newCache(time.Minute, 2, func() time.Time { return now })
+
+	loads := 0
+	load := func(key string) interface{} {
+		value, err := c.get(key, func() (interface{}, error) {
+			loads++
+			return key, nil
+		})
+		require.NoError(t, err)
+		return value
+	}
+
+	require.Equal(t, "a", load("a"))
+	require.Equal(t, "b", load("b"))
+	require.Equal(t, "a", load("a"))
+	// b is least recently used
+	require.Equal(t, "c", load("c"))
+	require.Len(t, c.entries, 2)
+	require.Equal(t, 3, loads)
+
+	require.Equal(t, "a", load("a"))
+	require.Equal(t, 3, loads)
+	require.Equal(t, "b", load("b"))
+	require.Equal(t, 4, loads)
+
+	// expired values are removed
+	now = now.Add(time.Minute)
+	_, ok := c.lookup("a")
+	require.False(t, ok)
+	require.Len(t, c.entries, 1)
+	require.Equal(t, "d", load("d"))
+	require.Len(t, c.entries, 1)
+	require.Equal(t, 1, c.order.Len())
+
+	// load locks are removed after loading, errors are not cached
+	_, err := c.get("e", func() (interface{}, error) { return nil, errors.New("failed") })
+	require.Error(t, err)
+	require.Empty(t, c.loading)
+	require.Len(t, c.entries, 1)
+}
@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// LockFileSuffix is added to directory name to get name of its lock file.
+	LockFileSuffix = ".lock"
+
+	DefaultLockLease = 30 * time.Minute
+)
+
+// Filesystem is a remote storage of lock files with conditional writes, e.g. webdav client.
+// Versions are compared by storage, so only one of concurrent writers changes lock file.
+type Filesystem interface {
+	// ReadVersion returns file content and its version, e.g. ETag.
+	ReadVersion(path string) ([]byte, string, error)
+	// WriteIfMatch writes file if its version is still version or if it doesn't exist for empty version,
+	// fails with error checked by IsPreconditionFailed otherwise. Returns new version of file.
+	WriteIfMatch(path string, data []byte, version string) (string, error)
+	// RemoveIfMatch removes file if its version is still version.
+	RemoveIfMatch(path, version string) error
+}
+
+// LockConfig describes lock of remote directory.
+type LockConfig struct {
+	Dir   string
+	Owner string
+	Lease time.Duration
+	// Break removes lock of another owner.
+	Break bool
+}
+
+// LockInfo is stored in lock file, so other writers can see who holds the lock.
+type LockInfo struct {
+	Owner      string    `json:"owner"`
+	Host       string    `json:"host"`
+	PID        int       `json:"pid"`
+	Token      string    `json:"token"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func (i LockInfo) String() string {
+	return fmt.Sprintf("%s (host %s, pid %d) since %s until %s",
+		i.Owner, i.Host, i.PID, i.AcquiredAt.Format(time.RFC3339), i.ExpiresAt.Format(time.RFC3339))
+}
+
+// LockedError is returned when directory is locked by another writer.
+type LockedError struct {
+	Dir    string
+	Holder LockInfo
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("remote directory %s is locked by %s, use --break-lock to remove the lock", e.Dir, e.Holder)
+}
+
+// Lock is an exclusive lock of remote directory implemented with lock file next to it,
+// because directory itself can be replaced while lock is held.
+// Lock lease is renewed in background until Release is called.
+type Lock struct {
+	fs       Filesystem
+	filePath string
+	lease    time.Duration
+
+	mu      sync.Mutex
+	info    LockInfo
+	version string
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// LockFilePath returns path of lock file for directory.
+func LockFilePath(dir string) string {
+	return path.Clean("/"+strings.Trim(dir, "/")) + LockFileSuffix
+}
+
+// DefaultLockOwner returns user and host of current process.
+func DefaultLockOwner() string {
+	user := os.Getenv("USER")
+	if user == "" {
+		user = "unknown"
+	}
+	host, _ := os.Hostname()
+	return user + "@" + host
+}
+
+// AcquireLock takes lock of cfg.Dir. It fails with *LockedError if lock file of another
+// owner exists and its lease is not expired, unless cfg.Break is set.
+// Lock file is created or replaced with conditional write, so concurrent writers can't both take the lock.
+func AcquireLock(fs Filesystem, cfg LockConfig) (*Lock, error) {
+	if cfg.Lease < 0 {
+		return nil, errors.Errorf("lock lease of %s must not be negative", cfg.Dir)
+	}
+	if cfg.Lease == 0 {
+		cfg.Lease = DefaultLockLease
+	}
+	if cfg.Owner == "" {
+		cfg.Owner = DefaultLockOwner()
+	}
+
+	filePath := LockFilePath(cfg.Dir)
+	holder, version, exists, err := readLockVersion(fs, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if exists && time.Now().Before(holder.ExpiresAt) {
+		if !cfg.Break {
+			return nil, &LockedError{Dir: cfg.Dir, Holder: holder}
+		}
+		log.Printf("breaking lock of %s held by %s", cfg.Dir, holder)
+	}
+
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	now := time.Now().UTC()
+	l := &Lock{
+		fs:       fs,
+		filePath: filePath,
+		lease:    cfg.Lease,
+		info: LockInfo{
+			Owner:      cfg.Owner,
+			Host:       host,
+			PID:        os.Getpid(),
+			Token:      token,
+			AcquiredAt: now,
+			ExpiresAt:  now.Add(cfg.Lease),
+		},
+		version: version,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if err := l.write(); err != nil {
+		if !IsPreconditionFailed(errors.Cause(err)) {
+			return nil, err
+		}
+		// another writer created or replaced lock file after it was read
+		holder, _, _, readErr := readLockVersion(fs, filePath)
+		if readErr != nil {
+			return nil, readErr
+		}
+		return nil, &LockedError{Dir: cfg.Dir, Holder: holder}
+	}
+
+	go l.keepAlive()
+	return l, nil
+}
+
+// Info returns current lock file content.
+func (l *Lock) Info() LockInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.info
+}
+
+// Release stops lease renewal and removes lock file if it is still ours.
+func (l *Lock) Release() error {
+	close(l.stop)
+	<-l.done
+
+	holder, version, exists, err := readLockVersion(l.fs, l.filePath)
+	if err != nil {
+		return err
+	}
+	if !exists || holder.Token != l.Info().Token {
+		return errors.Errorf("lock %s was taken by %s", l.filePath, holder)
+	}
+
+	if err := l.fs.RemoveIfMatch(l.filePath, version); err != nil {
+		if IsPreconditionFailed(err) {
+			return errors.Errorf("lock %s was taken by another writer", l.filePath)
+		}
+		return errors.Wrap(err, "failed to remove lock file")
+	}
+	return nil
+}
+
+func (l *Lock) keepAlive() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.lease / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			l.info.ExpiresAt = time.Now().UTC().Add(l.lease)
+			l.mu.Unlock()
+			if err := l.write(); err != nil {
+				// lock file of another writer must not be replaced, Release reports lost lock
+				if IsPreconditionFailed(errors.Cause(err)) {
+					log.Printf("lock %s was taken by another writer", l.filePath)
+					return
+				}
+				log.Printf("failed to renew lock %s: %v", l.filePath, err)
+			}
+		}
+	}
+}
+
+// write replaces lock file if it is not changed since last write.
+func (l *Lock) write() error {
+	data, err := json.Marshal(l.Info())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal lock info")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	version, err := l.fs.WriteIfMatch(l.filePath, data, l.version)
+	if err != nil {
+		return errors.Wrap(err, "failed to write lock file")
+	}
+	l.version = version
+	return nil
+}
+
+// ReadLock returns lock of dir if it is held by some writer and its lease is not expired.
+func ReadLock(fs DirReader, dir string) (LockInfo, bool, error) {
+	info, exists, err := readLockInfo(fs, LockFilePath(dir))
+	if err != nil || !exists || time.Now().After(info.ExpiresAt) {
+		return LockInfo{}, false, err
+	}
+	return info, true, nil
+}
+
+type fileReader interface {
+	Read(path string) ([]byte, error)
+}
+
+func readLockVersion(fs Filesystem, filePath string) (LockInfo, string, bool, error) {
+	data, version, err := fs.ReadVersion(filePath)
+	if err != nil {
+		if IsNotFound(err) {
+			return LockInfo{}, "", false, nil
+		}
+		return LockInfo{}, "", false, errors.Wrap(err, "failed to read lock file")
+	}
+
+	var info LockInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return LockInfo{}, "", false, errors.Wrap(err, "failed to unmarshal lock file")
+	}
+	return info, version, true, nil
+}
+
+func readLockInfo(fs fileReader, filePath string) (LockInfo, bool, error) {
+	data, err := fs.Read(filePath)
+	if err != nil {
+		if IsNotFound(err) {
+			return LockInfo{}, false, nil
+		}
+		return LockInfo{}, false, errors.Wrap(err, "failed to read lock file")
+	}
+
+	var info LockInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return LockInfo{}, false, errors.Wrap(err, "failed to unmarshal lock file")
+	}
+	return info, true, nil
+}
+
+func newLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Wrap(err, "failed to generate lock token")
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memFS keeps files with versions which are changed on every write.
+type memFS struct {
+	mu       sync.Mutex
+	files    map[string][]byte
+	versions map[string]string
+	next     int
+}
+
+func newMemFS() *memFS {
+	return &memFS{files: map[string][]byte{}, versions: map[string]string{}}
+}
+
+func (fs *memFS) Read(path string) ([]byte, error) {
+	data, _, err := fs.ReadVersion(path)
+	return data, err
+}
+
+func (fs *memFS) ReadVersion(path string) ([]byte, string, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	data, ok := fs.files[path]
+	if !ok {
+		return nil, "", &os.PathError{Op: "Read", Path: path, Err: os.ErrNotExist}
+	}
+	return data, fs.versions[path], nil
+}
+
+func (fs *memFS) WriteIfMatch(path string, data []byte, version string) (string, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.versions[path] != version {
+		return "", statusError("WriteIfMatch", path, http.StatusPreconditionFailed)
+	}
+	fs.next++
+	fs.files[path] = data
+	fs.versions[path] = strconv.Itoa(fs.next)
+	return fs.versions[path], nil
+}
+
+func (fs *memFS) RemoveIfMatch(path, version string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.versions[path] != version {
+		return statusError("RemoveIfMatch", path, http.StatusPreconditionFailed)
+	}
+	delete(fs.files, path)
+	delete(fs.versions, path)
+	return nil
+}
+
+func TestAcquireLock(t *testing.T) {
+	fs := newMemFS()
+	cfg := LockConfig{Dir: "/runs/fake/", Owner: "job-1", Lease: time.Minute}
+
+	lock, err := AcquireLock(fs, cfg)
+	require.NoError(t, err)
+	require.Contains(t, fs.files, "/runs/fake.lock")
+	require.Equal(t, "job-1", lock.Info().Owner)
+
+	t.Run("locked", func(t *testing.T) {
+		_, err := AcquireLock(fs, LockConfig{Dir: "runs/fake", Owner: "job-2"})
+		require.Error(t, err)
+		lockedErr, ok := err.(*LockedError)
+		require.True(t, ok)
+		require.Equal(t, "job-1", lockedErr.Holder.Owner)
+		require.Contains(t, err.Error(), "locked by job-1")
+		require.Contains(t, err.Error(), "--break-lock")
+	})
+
+	t.Run("break lock", func(t *testing.T) {
+		other, err := AcquireLock(fs, LockConfig{Dir: "runs/fake", Owner: "job-2", Break: true})
+		require.NoError(t, err)
+
+		err = lock.Release()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "job-2")
+
+		require.NoError(t, other.Release())
+		require.NotContains(t, fs.files, "/runs/fake.lock")
+	})
+
+	t.Run("expired lock", func(t *testing.T) {
+		expired, err := AcquireLock(fs, LockConfig{Dir: "runs/expired", Owner: "job-1", Lease: time.Hour})
+		require.NoError(t, err)
+		close(expired.stop)
+		<-expired.done
+		expired.info.ExpiresAt = time.Now().Add(-time.Second)
+		require.NoError(t, expired.write())
+
+		next, err := AcquireLock(fs, LockConfig{Dir: "runs/expired", Owner: "job-2"})
+		require.NoError(t, err)
+		require.NoError(t, next.Release())
+	})
+
+	t.Run("renew lease", func(t *testing.T) {
+		renewed, err := AcquireLock(fs, LockConfig{Dir: "runs/renewed", Lease: 20 * time.Millisecond})
+		require.NoError(t, err)
+		expiresAt := renewed.Info().ExpiresAt
+		require.Eventually(t, func() bool {
+			return renewed.Info().ExpiresAt.After(expiresAt)
+		}, time.Second, 5*time.Millisecond)
+		require.NoError(t, renewed.Release())
+	})
+
+	t.Run("root dir", func(t *testing.T) {
+		root, err := AcquireLock(fs, LockConfig{Dir: ""})
+		require.NoError(t, err)
+		require.Contains(t, fs.files, "/.lock")
+		require.NoError(t, root.Release())
+	})
+
+	t.Run("concurrent writers", func(t *testing.T) {
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			locks []*Lock
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l, err := AcquireLock(fs, LockConfig{Dir: "runs/concurrent"})
+				if err != nil {
+					require.IsType(t, &LockedError{}, err)
+					return
+				}
+				mu.Lock()
+				locks = append(locks, l)
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		require.Len(t, locks, 1)
+		require.NoError(t, locks[0].Release())
+	})
+
+	t.Run("negative lease", func(t *testing.T) {
+		_, err := AcquireLock(fs, LockConfig{Dir: "runs/negative", Lease: -time.Minute})
+		require.Error(t, err)
+		require.NotContains(t, fs.files, "/runs/negative.lock")
+	})
+}
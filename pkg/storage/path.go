@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var placeholderRegexp = regexp.MustCompile(`{[^{}]*}`)
+
+// ResolvePath replaces placeholders like {branch} in template with values from vars.
+// Values can be nested paths, use PathSegment for values which must be exactly one path level.
+func ResolvePath(template string, vars map[string]string) (string, error) {
+	var missing []string
+	resolved := placeholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := strings.Trim(placeholder, "{}")
+		value, ok := vars[name]
+		if !ok || value == "" {
+			missing = append(missing, placeholder)
+			return ""
+		}
+		return strings.Trim(value, "/")
+	})
+	if len(missing) > 0 {
+		return "", errors.Errorf("no values for %s in path template %q", strings.Join(missing, ", "), template)
+	}
+
+	resolved = path.Clean("/" + resolved)
+	if resolved == "/" {
+		return "", errors.Errorf("path template %q is resolved to root", template)
+	}
+	return strings.TrimPrefix(resolved, "/"), nil
+}
+
+// PathSegment replaces slashes in value with dashes, so value like git branch feature/name is one path level.
+func PathSegment(value string) string {
+	return strings.ReplaceAll(strings.Trim(value, "/"), "/", "-")
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePath(t *testing.T) {
+	vars := map[string]string{
+		"branch": PathSegment("feature/consensus"),
+		"base":   "consensus/reports/",
+		"hash":   "977022b",
+		"date":   "2020-06-17",
+		"empty":  "",
+	}
+
+	t.Run("positive", func(t *testing.T) {
+		p, err := ResolvePath("reports/{branch}/{date}/{hash}", vars)
+		require.NoError(t, err)
+		require.Equal(t, "reports/feature-consensus/2020-06-17/977022b", p)
+	})
+	t.Run("nested value", func(t *testing.T) {
+		p, err := ResolvePath("{base}/{branch}", vars)
+		require.NoError(t, err)
+		require.Equal(t, "consensus/reports/feature-consensus", p)
+	})
+	t.Run("clean", func(t *testing.T) {
+		p, err := ResolvePath("/{branch}//run-{hash}/", vars)
+		require.NoError(t, err)
+		require.Equal(t, "feature-consensus/run-977022b", p)
+	})
+	t.Run("unknown placeholder", func(t *testing.T) {
+		_, err := ResolvePath("{branch}/{unknown}/{empty}", vars)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "{unknown}, {empty}")
+	})
+	t.Run("root", func(t *testing.T) {
+		_, err := ResolvePath("/", vars)
+		require.Error(t, err)
+	})
+}
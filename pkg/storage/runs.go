@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/consensus-reports/pkg/replicator"
+)
+
+// DirReader is a remote storage which can be scanned for runs.
+type DirReader interface {
+	ReadDir(path string) ([]os.FileInfo, error)
+	Read(path string) ([]byte, error)
+}
+
+// Run is a replicated run directory with its index file.
+type Run struct {
+	Dir      string
+	Index    replicator.OutputConfig
+	Modified time.Time
+}
+
+// Date returns replication date from run metadata or directory modification time for old runs without it.
+func (r Run) Date() time.Time {
+	if date := r.Index.RunInfo().Date; !date.IsZero() {
+		return date
+	}
+	return r.Modified
+}
+
+// CommitDate returns commit time from run metadata or replication date if it is unknown.
+func (r Run) CommitDate() time.Time {
+	if date := r.Index.RunInfo().CommitDate; !date.IsZero() {
+		return date
+	}
+	return r.Date()
+}
+
+// Name returns run directory relative to root.
+func (r Run) Name(root string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(r.Dir, strings.Trim(root, "/")), "/")
+}
+
+// ListRuns walks root recursively and returns directories with index file sorted by date, newest first.
+// Hidden directories, e.g. staging ones, are skipped. Root itself is never a run, even with index file.
+func ListRuns(fs DirReader, root string) ([]Run, error) {
+	root = strings.Trim(root, "/")
+	runs, err := listRuns(fs, root, time.Time{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Date().After(runs[j].Date())
+	})
+	return runs, nil
+}
+
+func listRuns(fs DirReader, dir string, modified time.Time, root bool) ([]Run, error) {
+	entries, err := fs.ReadDir("/" + dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read dir %s", dir)
+	}
+
+	for _, e := range entries {
+		if root || e.IsDir() || e.Name() != replicator.DefaultConfigFilename {
+			continue
+		}
+
+		data, err := fs.Read(path.Join("/", dir, e.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read index of %s", dir)
+		}
+
+		run := Run{Dir: dir, Modified: modified}
+		if err := json.Unmarshal(data, &run.Index); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal index of %s", dir)
+		}
+		return []Run{run}, nil
+	}
+
+	var runs []Run
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		subRuns, err := listRuns(fs, strings.TrimPrefix(path.Join(dir, e.Name()), "/"), e.ModTime(), false)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, subRuns...)
+	}
+	return runs, nil
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/studio-b12/gowebdav"
+)
+
+// Client is a webdav client with conditional writes used by locks.
+type Client struct {
+	*gowebdav.Client
+	url      string
+	user     string
+	password string
+	http     *http.Client
+}
+
+// NewWebdavClient creates webdav client with timeout for all requests.
+func NewWebdavClient(url, user, password string, timeout time.Duration) *Client {
+	client := gowebdav.NewClient(url, user, password)
+	client.SetTimeout(timeout)
+	return &Client{Client: client, url: url, user: user, password: password, http: &http.Client{Timeout: timeout}}
+}
+
+// ReadVersion returns file content and its ETag.
+func (c *Client) ReadVersion(path string) ([]byte, string, error) {
+	rs, err := c.do(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rs.Body.Close()
+	if rs.StatusCode != http.StatusOK {
+		return nil, "", statusError("ReadVersion", path, rs.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(rs.Body)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to read %s", path)
+	}
+	return data, rs.Header.Get("ETag"), nil
+}
+
+// WriteIfMatch writes file only if its ETag is still version, or only if it doesn't exist for empty version.
+// Fails with error checked by IsPreconditionFailed if file is changed by someone else. Returns new ETag of file.
+func (c *Client) WriteIfMatch(path string, data []byte, version string) (string, error) {
+	header := http.Header{}
+	if version == "" {
+		header.Set("If-None-Match", "*")
+	} else {
+		header.Set("If-Match", version)
+	}
+	rs, err := c.do(http.MethodPut, path, data, header)
+	if err != nil {
+		return "", err
+	}
+	rs.Body.Close()
+	if rs.StatusCode != http.StatusOK && rs.StatusCode != http.StatusCreated && rs.StatusCode != http.StatusNoContent {
+		return "", statusError("WriteIfMatch", path, rs.StatusCode)
+	}
+	if etag := rs.Header.Get("ETag"); etag != "" {
+		return etag, nil
+	}
+
+	// some servers don't return ETag of written file
+	_, etag, err := c.ReadVersion(path)
+	return etag, err
+}
+
+// RemoveIfMatch removes file only if its ETag is still version.
+func (c *Client) RemoveIfMatch(path, version string) error {
+	rs, err := c.do(http.MethodDelete, path, nil, http.Header{"If-Match": {version}})
+	if err != nil {
+		return err
+	}
+	rs.Body.Close()
+	switch rs.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return statusError("RemoveIfMatch", path, rs.StatusCode)
+}
+
+func (c *Client) do(method, path string, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(method, gowebdav.PathEscape(gowebdav.Join(c.url, path)), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to make %s request of %s", method, path)
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	rs, err := c.http.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to %s %s", method, path)
+	}
+	return rs, nil
+}
+
+// statusError returns path error with status like errors of gowebdav client.
+func statusError(op, path string, status int) error {
+	return &os.PathError{Op: op, Path: path, Err: errors.New(strconv.Itoa(status))}
+}
+
+// IsNotFound checks webdav path error for 404 status.
+func IsNotFound(err error) bool {
+	return os.IsNotExist(err) || hasStatus(err, http.StatusNotFound)
+}
+
+// IsPreconditionFailed checks webdav path error for 412 status, e.g. of move without overwrite to existing path.
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+func hasStatus(err error, status int) bool {
+	pathErr, ok := errors.Cause(err).(*os.PathError)
+	return ok && pathErr.Err != nil && pathErr.Err.Error() == strconv.Itoa(status)
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WriteIfMatch(t *testing.T) {
+	files := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		require.Equal(t, "user:password", user+":"+password)
+
+		etag, exists := files[r.URL.Path]
+		switch {
+		case r.Header.Get("If-None-Match") == "*" && exists,
+			r.Header.Get("If-Match") != "" && r.Header.Get("If-Match") != etag:
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			_, _ = w.Write([]byte("data"))
+		case http.MethodPut:
+			files[r.URL.Path] = etag + "1"
+			w.Header().Set("ETag", files[r.URL.Path])
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			delete(files, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewWebdavClient(ts.URL, "user", "password", time.Second)
+	_, _, err := client.ReadVersion("/runs/fake.lock")
+	require.True(t, IsNotFound(err))
+
+	version, err := client.WriteIfMatch("/runs/fake.lock", []byte("data"), "")
+	require.NoError(t, err)
+	require.Equal(t, "1", version)
+
+	_, err = client.WriteIfMatch("/runs/fake.lock", []byte("data"), "")
+	require.True(t, IsPreconditionFailed(err))
+
+	data, version, err := client.ReadVersion("/runs/fake.lock")
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+	require.Equal(t, "1", version)
+
+	version, err = client.WriteIfMatch("/runs/fake.lock", []byte("data"), version)
+	require.NoError(t, err)
+	require.Equal(t, "11", version)
+
+	require.True(t, IsPreconditionFailed(client.RemoveIfMatch("/runs/fake.lock", "1")))
+	require.NoError(t, client.RemoveIfMatch("/runs/fake.lock", version))
+	require.Empty(t, files)
+}